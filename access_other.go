@@ -0,0 +1,46 @@
+//go:build !unix
+
+package config
+
+import (
+	"errors"
+	"os"
+)
+
+// canRead reports whether the process may read the file or directory at path.
+func canRead(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// canWrite reports whether the process may write the file at path or create
+// files in the directory at path, without changing either. Directories are
+// only checked for the read-only attribute.
+func canWrite(path string, dir bool) error {
+	if dir {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if info.Mode().Perm()&0o200 == 0 {
+			return errors.New("read-only directory")
+		}
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// canExec reports whether the file described by info has an execute bit set.
+func canExec(_ string, info os.FileInfo) error {
+	if info.Mode().Perm()&0o111 == 0 {
+		return errors.New("no execute permission")
+	}
+	return nil
+}
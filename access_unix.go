@@ -0,0 +1,25 @@
+//go:build unix
+
+package config
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// canRead reports whether the process may read the file or directory at path.
+func canRead(path string) error {
+	return unix.Access(path, unix.R_OK)
+}
+
+// canWrite reports whether the process may write the file at path or create
+// files in the directory at path, without changing either.
+func canWrite(path string, _ bool) error {
+	return unix.Access(path, unix.W_OK)
+}
+
+// canExec reports whether the process may execute the file at path.
+func canExec(path string, _ os.FileInfo) error {
+	return unix.Access(path, unix.X_OK)
+}
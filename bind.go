@@ -4,7 +4,9 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,14 +15,16 @@ import (
 
 // Bind maps configuration values from the Config instance into a structured
 // Go type. It uses struct tags to determine how to bind the data and can also
-// perform validation.
+// perform validation. Structs implementing Validator or ValidatorWithConfig
+// are checked once their fields are bound and valid.
 //
 // Parameters:
 //   - prefix: The prefix to prepend to all configuration keys
 //   - v: A pointer to a struct where the configuration values will be populated
 //
 // Returns:
-//   - error: If the input is not a non-nil pointer, or if binding fails
+//   - error: If the input is not a non-nil pointer, or BindErrors listing
+//     every field that failed to bind or validate
 func (c *Config) Bind(prefix string, v any) error {
 	rv := reflect.ValueOf(v)
 	if rv.Kind() != reflect.Pointer || rv.IsNil() {
@@ -31,7 +35,168 @@ func (c *Config) Bind(prefix string, v any) error {
 		prefix = strings.Trim(prefix, ".")
 	}
 
-	return c.bindValue(rv.Elem(), prefix)
+	var errs BindErrors
+	errs = c.appendBindError(errs, c.bindValue(rv.Elem(), prefix), prefix, rv.Elem().Type())
+	return errs.err()
+}
+
+// BindError describes a value that failed to bind or validate.
+type BindError struct {
+	// Key is the full key of the value, such as "servers.0.port".
+	Key string
+	// Type is the type of the field the value was bound to.
+	Type reflect.Type
+	// Value is the raw value of the key, or nil if the key is not set.
+	Value any
+	// Origin is the provider that supplied Value. It is zero if the key is
+	// not set.
+	Origin Provenance
+	// Rule is the "check" rule that failed, or empty if the value couldn't
+	// be converted.
+	Rule string
+	// Err is the cause.
+	Err error
+}
+
+func (be BindError) Error() string {
+	// Custom messages are complete, and may name the key themselves
+	if ve, ok := be.Err.(ValidationError); ok {
+		if msg, ok := ve.message(); ok {
+			return msg
+		}
+	}
+	if be.Key == "" && be.Rule == "" {
+		return be.Err.Error()
+	}
+	if be.Key == "" {
+		return fmt.Sprintf("%s: %v", be.Rule, be.Err)
+	}
+	if be.Rule != "" {
+		return fmt.Sprintf("%s: %s: %v", be.Key, be.Rule, be.Err)
+	}
+	return fmt.Sprintf("%s: %v", be.Key, be.Err)
+}
+
+func (be BindError) Unwrap() error {
+	return be.Err
+}
+
+// BindErrors lists every value that failed to bind or validate. Use errors.As
+// with a BindError to get the first one.
+type BindErrors []BindError
+
+func (be BindErrors) Error() string {
+	msgs := make([]string, 0, len(be))
+	for e := range slices.Values(be) {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (be BindErrors) Unwrap() []error {
+	errs := make([]error, 0, len(be))
+	for e := range slices.Values(be) {
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+// err returns be, or nil if be is empty.
+func (be BindErrors) err() error {
+	if len(be) == 0 {
+		return nil
+	}
+	return be
+}
+
+// appendBindError appends err to errs. BindError and BindErrors are appended
+// as they are; other errors are described as a BindError for the key and the
+// type. c may be nil, as in ValidateStruct, leaving the value and the origin
+// of the BindError empty.
+func (c *Config) appendBindError(errs BindErrors, err error, key string, t reflect.Type) BindErrors {
+	var be BindError
+	var bes BindErrors
+	switch {
+	case err == nil:
+		return errs
+	case errors.As(err, &bes):
+		return append(errs, bes...)
+	case errors.As(err, &be):
+		return append(errs, be)
+	}
+
+	var ee elementError
+	if errors.As(err, &ee) {
+		key, t, err = ee.key, ee.typ, ee.err
+	}
+
+	be = BindError{Key: key, Type: t, Err: err}
+	var ve ValidationError
+	var re ruleError
+	switch {
+	case errors.As(err, &ve):
+		be.Rule = ve.Rule
+		be.Err = ve
+	case errors.As(err, &re):
+		be.Rule = re.rule
+		be.Err = re.err
+	}
+	if c == nil {
+		return append(errs, be)
+	}
+	if origin, err := c.Origin(key); err == nil {
+		be.Value = origin.Value
+		be.Origin = origin
+	}
+	return append(errs, be)
+}
+
+// isTextType reports whether values of type t, or of the type t points to, are
+// bound from a single string: types implementing encoding.TextUnmarshaler,
+// such as net.IP, netip.Addr and netip.Prefix, and url.URL. time.Time is bound
+// like other values, so it accepts every format cast understands.
+func isTextType(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+	return t == reflect.TypeOf(url.URL{}) || t.Implements(textUnmarshalerType) ||
+		reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// bindText binds the string value of key to rv, whose type is a text type as
+// reported by isTextType. Nil pointers are allocated.
+func (c *Config) bindText(rv reflect.Value, key string) (bool, error) {
+	got, err := c.GetReflectionE(key)
+	if err != nil {
+		return false, err
+	}
+	str, err := cast.ToStringE(got.Interface())
+	if err != nil {
+		return false, fmt.Errorf("cannot convert %v to %v: %v", got.Type(), rv.Type(), err)
+	}
+
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Type() == reflect.TypeOf(url.URL{}) {
+		u, err := url.Parse(str)
+		if err != nil {
+			return false, err
+		}
+		rv.Set(reflect.ValueOf(*u))
+		return true, nil
+	}
+	if err := rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(str)); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 func (c *Config) bindValue(rv reflect.Value, key string) error {
@@ -43,10 +208,13 @@ func (c *Config) bindValue(rv reflect.Value, key string) error {
 		rv = rv.Elem()
 	}
 
-	if rv.CanInterface() {
-		if text, ok := rv.Interface().(encoding.TextUnmarshaler); ok {
-			return text.UnmarshalText([]byte(c.GetString(key)))
+	if isTextType(rv.Type()) {
+		if _, err := c.bindText(rv, key); err != nil {
+			if _, ok := err.(KeyError); !ok {
+				return err
+			}
 		}
+		return nil
 	}
 
 	switch rv.Kind() {
@@ -75,7 +243,32 @@ func (c *Config) bindValue(rv reflect.Value, key string) error {
 	}
 }
 
+// fieldKey returns the key of the struct field sf below prefix: its "config"
+// tag, or its name without one. ok is false for fields tagged "-".
+func fieldKey(sf reflect.StructField, prefix string) (key string, ok bool) {
+	cfgTag := strings.TrimSpace(sf.Tag.Get("config"))
+	if cfgTag == "-" {
+		return "", false
+	}
+
+	key = cfgTag
+	if key == "" {
+		key = sf.Name
+	}
+	key = strings.Trim(key, ".")
+	if prefix != "" {
+		if key != "" {
+			key = prefix + "." + key
+		} else {
+			key = prefix
+		}
+	}
+	return key, true
+}
+
 func (c *Config) bindStruct(rv reflect.Value, prefix string) error {
+	var errs BindErrors
+	var bound []RuleContext
 	rt := rv.Type()
 	for i := 0; i < rt.NumField(); i++ {
 		sf := rt.Field(i)
@@ -84,32 +277,14 @@ func (c *Config) bindStruct(rv reflect.Value, prefix string) error {
 		}
 
 		field := rv.Field(i)
-		cfgTag := strings.TrimSpace(sf.Tag.Get("config"))
-		ignoreTag := strings.TrimSpace(sf.Tag.Get("config")) == "-"
-
-		if ignoreTag {
+		key, ok := fieldKey(sf, prefix)
+		if !ok {
 			continue
 		}
 
-		// Build key path
-		key := cfgTag
-		if key == "" {
-			key = sf.Name
-		}
-		key = strings.Trim(key, ".")
-		if prefix != "" {
-			if key != "" {
-				key = prefix + "." + key
-			} else {
-				key = prefix
-			}
-		}
-
 		// Handle embedded structs
 		if sf.Anonymous {
-			if err := c.bindValue(field, prefix); err != nil {
-				return err
-			}
+			errs = c.appendBindError(errs, c.bindValue(field, prefix), prefix, sf.Type)
 			continue
 		}
 
@@ -118,8 +293,10 @@ func (c *Config) bindStruct(rv reflect.Value, prefix string) error {
 		var err error
 
 		// Handle different field types
-		switch field.Kind() {
-		case reflect.Struct:
+		switch kind := field.Kind(); {
+		case isTextType(field.Type()):
+			changed, err = c.bindText(field, key)
+		case kind == reflect.Struct:
 			if field.Type() == reflect.TypeOf(time.Time{}) {
 				changed, err = c.bindPrimitive(field, key)
 			} else {
@@ -130,9 +307,9 @@ func (c *Config) bindStruct(rv reflect.Value, prefix string) error {
 					changed = false
 				}
 			}
-		case reflect.Slice, reflect.Array:
+		case kind == reflect.Slice, kind == reflect.Array:
 			changed, err = c.bindSliceOrArray(field, key)
-		case reflect.Map:
+		case kind == reflect.Map:
 			changed, err = c.bindMap(field, key)
 		default:
 			changed, err = c.bindPrimitive(field, key)
@@ -140,17 +317,33 @@ func (c *Config) bindStruct(rv reflect.Value, prefix string) error {
 
 		if err != nil {
 			if _, ok := err.(KeyError); !ok {
-				return err
+				errs = c.appendBindError(errs, err, key, sf.Type)
+				continue
 			}
 			changed = false
 		}
 
 		// Validate the field with the correct changed status
-		if err := Validate(sf, field, changed); err != nil {
-			return fmt.Errorf("%s: %v", key, err)
+		ctx := RuleContext{Field: sf, Value: field, Parent: rv, Key: key, Changed: changed, config: c}
+		if err := validateField(ctx, false); err != nil {
+			errs = c.appendBindError(errs, err, key, sf.Type)
+			continue
 		}
+		bound = append(bound, ctx)
 	}
-	return nil
+
+	// Cross-field rules see the other fields, so they run once all are bound
+	for ctx := range slices.Values(bound) {
+		if err := validateField(ctx, true); err != nil {
+			errs = c.appendBindError(errs, err, ctx.Key, ctx.Field.Type)
+		}
+	}
+
+	// Struct-level checks assume valid fields, so they only run without errors
+	if len(errs) == 0 {
+		errs = c.appendBindError(errs, c.callValidators(rv, prefix), prefix, rt)
+	}
+	return errs.err()
 }
 
 func (c *Config) bindSliceOrArray(rv reflect.Value, key string) (bool, error) {
@@ -160,15 +353,14 @@ func (c *Config) bindSliceOrArray(rv reflect.Value, key string) (bool, error) {
 	}
 
 	if configVal.Kind() != reflect.Slice && configVal.Kind() != reflect.Array {
-		return false, fmt.Errorf("config value for %s is not a slice or array", key)
+		return false, fmt.Errorf("%v is not a slice or array", configVal.Type())
 	}
 
 	length := configVal.Len()
 	elemType := rv.Type().Elem()
 
 	if rv.Kind() == reflect.Array && rv.Len() != length {
-		return false, fmt.Errorf("array size mismatch for %s: expected %d, got %d",
-			key, rv.Len(), length)
+		return false, fmt.Errorf("array size mismatch: expected %d, got %d", rv.Len(), length)
 	}
 
 	var container reflect.Value
@@ -178,6 +370,7 @@ func (c *Config) bindSliceOrArray(rv reflect.Value, key string) (bool, error) {
 		container = rv
 	}
 
+	var errs BindErrors
 	for i := range length {
 		elemVal := configVal.Index(i)
 		if elemVal.Kind() == reflect.Interface {
@@ -186,21 +379,23 @@ func (c *Config) bindSliceOrArray(rv reflect.Value, key string) (bool, error) {
 
 		elem := container.Index(i)
 		elemKey := fmt.Sprintf("%s.%d", key, i)
+		if !elemVal.IsValid() {
+			// null elements keep the zero value
+			continue
+		}
 
 		if elem.Kind() == reflect.Pointer && elem.IsNil() {
 			elem.Set(reflect.New(elem.Type().Elem()))
 		}
 
-		if elemVal.IsValid() && (elem.Kind() == reflect.Struct ||
-			elem.Kind() == reflect.Slice || elem.Kind() == reflect.Array ||
-			elem.Kind() == reflect.Map) {
-			if err := c.bindValue(elem, elemKey); err != nil {
-				return true, err
-			}
+		if elem.Kind() == reflect.Struct || elem.Kind() == reflect.Slice ||
+			elem.Kind() == reflect.Array || elem.Kind() == reflect.Map {
+			errs = c.appendBindError(errs, c.bindValue(elem, elemKey), elemKey, elemType)
 		} else {
 			converted, err := c.convertValue(elemVal.Interface(), elemType)
 			if err != nil {
-				return true, fmt.Errorf("%s[%d]: %v", key, i, err)
+				errs = c.appendBindError(errs, err, elemKey, elemType)
+				continue
 			}
 			elem.Set(reflect.ValueOf(converted))
 		}
@@ -210,7 +405,26 @@ func (c *Config) bindSliceOrArray(rv reflect.Value, key string) (bool, error) {
 		rv.Set(container)
 	}
 
-	return true, nil // Changed because we set the value
+	return true, errs.err() // Changed because we set the value
+}
+
+// isContainerType reports whether values of type t, or of the type t points
+// to, are bound key by key: structs other than text types and time.Time,
+// slices, arrays and maps.
+func isContainerType(t reflect.Type) bool {
+	if isTextType(t) {
+		return false
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != reflect.TypeOf(time.Time{})
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
 }
 
 func (c *Config) bindMap(rv reflect.Value, key string) (bool, error) {
@@ -220,7 +434,7 @@ func (c *Config) bindMap(rv reflect.Value, key string) (bool, error) {
 	}
 
 	if configVal.Kind() != reflect.Map {
-		return false, fmt.Errorf("config value for %s is not a map", key)
+		return false, fmt.Errorf("%v is not a map", configVal.Type())
 	}
 
 	mapType := rv.Type()
@@ -228,28 +442,36 @@ func (c *Config) bindMap(rv reflect.Value, key string) (bool, error) {
 	valueType := mapType.Elem()
 	newMap := reflect.MakeMap(mapType)
 
+	var errs BindErrors
 	for _, k := range configVal.MapKeys() {
 		v := configVal.MapIndex(k)
 		if v.Kind() == reflect.Interface {
 			v = reflect.ValueOf(v.Interface())
 		}
 
+		elemKey := key + "." + formatKey([]KeyPart{{StringKey, cast.ToString(k.Interface())}})
 		keyVal, err := c.convertValue(k.Interface(), keyType)
 		if err != nil {
-			return true, fmt.Errorf("%s: key conversion error: %v", key, err)
+			errs = c.appendBindError(errs, fmt.Errorf("key conversion error: %v", err), elemKey, keyType)
+			continue
 		}
 
 		valueVal := reflect.New(valueType).Elem()
-		elemKey := fmt.Sprintf("%s.%s", key, cast.ToString(k.Interface()))
 
 		if valueVal.Kind() == reflect.Pointer && valueVal.IsNil() {
 			valueVal.Set(reflect.New(valueType.Elem()))
 		}
 
-		if err := c.bindValue(valueVal, elemKey); err != nil {
+		if isContainerType(valueType) {
+			if err := c.bindValue(valueVal, elemKey); err != nil {
+				errs = c.appendBindError(errs, err, elemKey, valueType)
+				continue
+			}
+		} else if err := c.bindValue(valueVal, elemKey); err != nil {
 			converted, err := c.convertValue(v.Interface(), valueType)
 			if err != nil {
-				return true, fmt.Errorf("%s[%v]: %v", key, k.Interface(), err)
+				errs = c.appendBindError(errs, err, elemKey, valueType)
+				continue
 			}
 			valueVal = reflect.ValueOf(converted)
 		}
@@ -258,7 +480,7 @@ func (c *Config) bindMap(rv reflect.Value, key string) (bool, error) {
 	}
 
 	rv.Set(newMap)
-	return true, nil // Changed because we set the value
+	return true, errs.err() // Changed because we set the value
 }
 
 func (c *Config) bindPrimitive(rv reflect.Value, key string) (bool, error) {
@@ -269,8 +491,7 @@ func (c *Config) bindPrimitive(rv reflect.Value, key string) (bool, error) {
 
 	converted, err := c.convertValue(got.Interface(), rv.Type())
 	if err != nil {
-		return false, fmt.Errorf("%s: cannot convert %v to %v: %v",
-			key, got.Type(), rv.Type(), err)
+		return false, fmt.Errorf("cannot convert %v to %v: %v", got.Type(), rv.Type(), err)
 	}
 
 	if rv.CanSet() {
@@ -281,7 +502,7 @@ func (c *Config) bindPrimitive(rv reflect.Value, key string) (bool, error) {
 		} else if cv.Type().ConvertibleTo(rv.Type()) {
 			rv.Set(cv.Convert(rv.Type()))
 		} else {
-			return false, fmt.Errorf("%s: %v is not assignable to %v", key, got.Type(), rv.Type())
+			return false, fmt.Errorf("%v is not assignable to %v", got.Type(), rv.Type())
 		}
 	}
 
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"errors"
+	"os"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -172,3 +175,136 @@ type ServerTLS struct {
 	Cert    string `config:"cert" check:"required"`
 	Key     string `config:"key"`
 }
+
+func TestBindSliceOfStructs(t *testing.T) {
+	c := New()
+	c.Set("servers[0].addr", ":8080")
+	c.Set("servers[1].addr", ":9090")
+	c.Set("servers[1].read_timeout", "10s")
+
+	var config struct {
+		Servers []ServerConfig `config:"servers"`
+	}
+	if err := c.Bind("", &config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(config.Servers) != 2 {
+		t.Fatalf("expected 2 servers, got %d", len(config.Servers))
+	}
+	if config.Servers[1].Addr != ":9090" {
+		t.Errorf("expected Servers[1].Addr=:9090, got %q", config.Servers[1].Addr)
+	}
+	if config.Servers[1].ReadTimeout != 10*time.Second {
+		t.Errorf("expected Servers[1].ReadTimeout=10s, got %v", config.Servers[1].ReadTimeout)
+	}
+	if config.Servers[0].ReadTimeout != 30*time.Second {
+		t.Errorf("expected Servers[0].ReadTimeout=30s, got %v", config.Servers[0].ReadTimeout)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	_ = os.Setenv("BINDERR_SERVERS___1__PORT", "not-a-port")
+	defer os.Unsetenv("BINDERR_SERVERS___1__PORT")
+
+	c := New()
+	c.SetEnvPrefix("BINDERR")
+	c.Set("read_timeout", "soon")
+	c.Set("tls.enabled", true)
+	c.Set("servers", []any{
+		map[string]any{"port": 80},
+		map[string]any{"port": 81},
+		map[string]any{"port": "eighty-two"},
+	})
+
+	var config struct {
+		ServerConfig `config:""`
+		Servers      []struct {
+			Port int `config:"port"`
+		} `config:"servers"`
+	}
+	err := c.Bind("", &config)
+
+	var errs BindErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Bind() error = %v, want BindErrors", err)
+	}
+
+	got := map[string]BindError{}
+	for _, e := range errs {
+		got[e.Key] = e
+	}
+	if len(got) != 4 {
+		t.Fatalf("got %d errors, want 4:\n%v", len(got), err)
+	}
+
+	tests := []struct {
+		key   string
+		typ   reflect.Type
+		value any
+		layer Layer
+		rule  string
+	}{
+		{"read_timeout", reflect.TypeOf(time.Duration(0)), "soon", LayerFile, ""},
+		{"tls.cert", reflect.TypeOf(""), nil, 0, "required"},
+		{"servers.1.port", reflect.TypeOf(0), "not-a-port", LayerEnv, ""},
+		{"servers.2.port", reflect.TypeOf(0), "eighty-two", LayerFile, ""},
+	}
+	for _, tt := range tests {
+		e, ok := got[tt.key]
+		if !ok {
+			t.Errorf("missing error for %s", tt.key)
+			continue
+		}
+		if e.Type != tt.typ || e.Value != tt.value || e.Origin.Layer != tt.layer || e.Rule != tt.rule {
+			t.Errorf("%s: got {Type: %v, Value: %v, Layer: %v, Rule: %q}, want {%v, %v, %v, %q}",
+				tt.key, e.Type, e.Value, e.Origin.Layer, e.Rule, tt.typ, tt.value, tt.layer, tt.rule)
+		}
+	}
+
+	var first BindError
+	if !errors.As(err, &first) || first.Key != "read_timeout" {
+		t.Errorf("errors.As(BindError) = %v, want the read_timeout error", first)
+	}
+}
+
+func TestBindNullListElement(t *testing.T) {
+	c := New()
+	c.Set("ports", []any{80, nil, 443})
+
+	var v struct {
+		Ports []int `config:"ports"`
+	}
+	if err := c.Bind("", &v); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if want := []int{80, 0, 443}; !reflect.DeepEqual(v.Ports, want) {
+		t.Errorf("Ports = %v, want %v", v.Ports, want)
+	}
+}
+
+func TestBindMapOfStructs(t *testing.T) {
+	c := New()
+	c.Set("servers.a.port", 0)
+	c.Set("servers.b.port", 8080)
+
+	type Server struct {
+		Port int `config:"port" check:"min=1"`
+	}
+	var config struct {
+		Servers map[string]Server `config:"servers"`
+	}
+	err := c.Bind("", &config)
+
+	var errs BindErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Bind() error = %v, want BindErrors", err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("got %d errors, want 1:\n%v", len(errs), err)
+	}
+	if e := errs[0]; e.Key != "servers.a.port" || e.Rule != "min" || e.Value != 0 {
+		t.Errorf("got {Key: %s, Rule: %q, Value: %v}, want {servers.a.port, \"min\", 0}",
+			e.Key, e.Rule, e.Value)
+	}
+}
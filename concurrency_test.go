@@ -0,0 +1,153 @@
+package config_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Nadim147c/go-config"
+	"github.com/spf13/pflag"
+)
+
+// These tests are meant to be run with the race detector (go test -race).
+
+func TestConcurrentReadWrite(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "config.yaml"), "app:\n  port: 8080\n  name: MyApp\n")
+
+	c := config.New()
+	c.AddPath(dir)
+	if err := c.ReadConfig(); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+
+	type App struct {
+		Port int    `config:"port"`
+		Name string `config:"name"`
+		Env  string `config:"env" check:"default=dev"`
+	}
+
+	const workers = 8
+	const iterations = 200
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			for range iterations {
+				c.GetIntE("app.port")
+				c.GetStringE("app.name")
+				c.Settings()
+				c.Keys()
+				if _, err := c.Query("app.*"); err != nil {
+					t.Errorf("Query() error = %v", err)
+				}
+				var app App
+				c.Bind("app", &app)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			for i := range iterations {
+				switch i % 6 {
+				case 0:
+					c.Set("app.port", 9000+i)
+				case 1:
+					c.SetDefault(fmt.Sprintf("worker.%d", w), i)
+				case 2:
+					c.ReadConfig()
+				case 3:
+					c.SetEnvPrefix("CONCURRENT")
+				case 4:
+					fs := pflag.NewFlagSet("app", pflag.ContinueOnError)
+					fs.String("name", "", "")
+					c.AddPflag("app.name", fs.Lookup("name"))
+				case 5:
+					c.Set("servers[1].host", "b.example.com")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentWatchReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	writeFile(t, path, `{"counter": 0}`)
+
+	c := config.New()
+	c.AddPath(dir)
+	if err := c.ReadConfig(); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+
+	events := make(chan config.Event, 100)
+	c.OnConfigChange(func(e config.Event) { events <- e })
+	c.SetWatchDebounce(time.Millisecond)
+	if err := c.WatchConfig(); err != nil {
+		t.Fatalf("WatchConfig() error = %v", err)
+	}
+	defer c.StopWatchConfig()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					c.GetIntE("counter")
+					c.Settings()
+				}
+			}
+		}()
+	}
+
+	for i := 1; i <= 5; i++ {
+		writeFile(t, path, fmt.Sprintf(`{"counter": %d}`, i))
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	deadline := time.After(5 * time.Second)
+	for c.GetInt("counter") != 5 {
+		select {
+		case <-events:
+		case <-deadline:
+			t.Fatalf("counter = %d, want %d", c.GetInt("counter"), 5)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestSnapshotIsolation(t *testing.T) {
+	c := config.New()
+	c.Set("app.port", 8080)
+
+	before := c.GetStringMapMust("app")
+	c.Set("app.port", 9090)
+	c.Set("app.name", "MyApp")
+
+	if before["port"] != 8080 {
+		t.Errorf("old snapshot port = %v, want %v", before["port"], 8080)
+	}
+	if _, ok := before["name"]; ok {
+		t.Error("old snapshot should not contain app.name")
+	}
+
+	settings := c.Settings()
+	settings["app"].(map[string]any)["port"] = 1
+	if port := c.GetIntMust("app.port"); port != 9090 {
+		t.Errorf("app.port = %d after modifying Settings(), want %d", port, 9090)
+	}
+}
@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/BurntSushi/toml"
 	"github.com/goccy/go-yaml"
@@ -28,6 +31,43 @@ func (ke KeyError) Error() string {
 	return fmt.Sprintf("key not found: %v", ke.Key)
 }
 
+// ErrNoConfig is returned by ReadConfig when no config file could be loaded.
+var ErrNoConfig = errors.New("No configuration found")
+
+// FileError indicates a config file or an included file failed to load.
+type FileError struct {
+	// Path is the file that failed to load.
+	Path string
+	// IncludedFrom lists the files that included Path, outermost first. It
+	// is empty for files found by GetConfigFiles.
+	IncludedFrom []string
+	// Err is the cause.
+	Err error
+}
+
+func (fe FileError) Error() string {
+	if len(fe.IncludedFrom) == 0 {
+		return fmt.Sprintf("%s: %v", fe.Path, fe.Err)
+	}
+	from := strings.Join(fe.IncludedFrom, " → ")
+	return fmt.Sprintf("%s (included from %s): %v", fe.Path, from, fe.Err)
+}
+
+func (fe FileError) Unwrap() error {
+	return fe.Err
+}
+
+// CycleError indicates a config file includes itself, directly or through
+// other files.
+type CycleError struct {
+	// Chain is the include chain, starting and ending with the same file.
+	Chain []string
+}
+
+func (ce CycleError) Error() string {
+	return fmt.Sprintf("cycle import detected: %s", strings.Join(ce.Chain, " → "))
+}
+
 // Must indicates that there Must not be any error; it panics if an error
 // occurs.
 func Must[T any](v T, err error) T {
@@ -90,31 +130,83 @@ func Default() *Config {
 // configuration values loaded from files, environment variables, or other
 // sources. The struct also manages metadata and encoding/decoding behavior for
 // configuration data.
+//
+// A Config is safe for concurrent use. Values are read from an immutable
+// snapshot that writers such as Set and ReadConfig replace atomically, so
+// reads never take a lock. Maps and slices returned by getters belong to the
+// snapshot and must not be modified.
 type Config struct {
-	defaults map[string]any
-	config   map[string]any
+	// mu serializes writers and guards the file settings below.
+	mu    sync.Mutex
+	state atomic.Pointer[snapshot]
 
-	pflagSet *pflag.FlagSet
-	pflags   map[string]*pflag.Flag
-
-	envPrefix string
-	logger    *slog.Logger
+	logger atomic.Pointer[slog.Logger]
 
 	paths         []string
 	fullPath      map[string]bool
 	defaultFormat string
+	strict        bool
 	fileName      string
 
 	decoders map[string]DecodeFunc
 	encoders map[string]EncodeFunc
+
+	watch watchState
+
+	// parent and root are set for views created by Sub.
+	parent *Config
+	root   []KeyPart
+}
+
+// snapshot holds the configuration values of a Config. A snapshot is never
+// modified once stored; writers store an updated copy instead.
+type snapshot struct {
+	defaults map[string]any
+	config   map[string]any
+
+	pflagSet *pflag.FlagSet
+	pflags   map[string]*pflag.Flag
+
+	envPrefix string
+
+	// providers are the registered providers sorted by priority, highest
+	// first.
+	providers []providerEntry
+
+	// files lists every file read by the last ReadConfig, including
+	// included files.
+	files []string
+	// origins maps keys of config to the include chain of the file that set
+	// them. Keys set with Set have an empty chain.
+	origins map[string][]string
+}
+
+// load returns the current snapshot.
+func (c *Config) load() *snapshot {
+	return c.state.Load()
+}
+
+// update applies fn to a copy of the current snapshot and stores the copy if
+// fn succeeds. fn must not modify maps or slices shared with the old snapshot.
+func (c *Config) update(fn func(s *snapshot) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.updateLocked(fn)
+}
+
+// updateLocked is like update but the caller must hold c.mu.
+func (c *Config) updateLocked(fn func(s *snapshot) error) error {
+	s := *c.load()
+	if err := fn(&s); err != nil {
+		return err
+	}
+	c.state.Store(&s)
+	return nil
 }
 
 // New creates Config instance.
 func New() *Config {
-	return &Config{
-		logger:   slog.Default(),
-		defaults: map[string]any{},
-		config:   map[string]any{},
+	c := &Config{
 		fullPath: map[string]bool{},
 		fileName: "config",
 		encoders: map[string]EncodeFunc{
@@ -135,22 +227,32 @@ func New() *Config {
 		},
 		defaultFormat: "yaml",
 	}
+	c.logger.Store(slog.Default())
+	c.state.Store(&snapshot{
+		defaults:  map[string]any{},
+		config:    map[string]any{},
+		providers: c.defaultProviders(),
+	})
+	return c
 }
 
 // SetPflagSet adds *pflag.FlagSet
 func (c *Config) SetPflagSet(fs *pflag.FlagSet) {
-	c.pflagSet = fs
+	_ = c.update(func(s *snapshot) error {
+		s.pflagSet = fs
+		return nil
+	})
 }
 
 // SetLogger sets logger
 func (c *Config) SetLogger(l *slog.Logger) {
-	c.logger = l
+	c.logger.Store(l)
 }
 
 // GetLogger returns the configured logger, or a no-op logger if none is set.
 func (c *Config) GetLogger() *slog.Logger {
-	if c.logger != nil {
-		return c.logger
+	if l := c.logger.Load(); l != nil {
+		return l
 	}
 	// Return a dummy logger that discards all logs
 	return slog.New(slog.NewTextHandler(io.Discard, nil))
@@ -158,13 +260,18 @@ func (c *Config) GetLogger() *slog.Logger {
 
 // AddPflag adds *pflag.FlagSet
 func (c *Config) AddPflag(name string, f *pflag.Flag) {
-	if c.pflags == nil {
-		c.pflags = map[string]*pflag.Flag{}
-	}
 	if name == "" {
 		name = f.Name
 	}
-	c.pflags[name] = f
+	_ = c.update(func(s *snapshot) error {
+		pflags := maps.Clone(s.pflags)
+		if pflags == nil {
+			pflags = map[string]*pflag.Flag{}
+		}
+		pflags[name] = f
+		s.pflags = pflags
+		return nil
+	})
 }
 
 // SetEnvPrefix sets the environment variable prefix for the configuration.
@@ -172,7 +279,10 @@ func (c *Config) AddPflag(name string, f *pflag.Flag) {
 //
 // For example, calling SetEnvPrefix("APP_") will set the prefix to "APP".
 func (c *Config) SetEnvPrefix(p string) {
-	c.envPrefix = strings.TrimSuffix(p, "_")
+	_ = c.update(func(s *snapshot) error {
+		s.envPrefix = strings.TrimSuffix(p, "_")
+		return nil
+	})
 }
 
 // basenameWithoutExt return filename without extension
@@ -190,6 +300,13 @@ func basenameWithoutExt(path string) string {
 // Example: fileName "config", path "/etc/app" → matches "/etc/app/config.json",
 // "/etc/app/config.yaml", etc.
 func (c *Config) GetConfigFiles() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getConfigFiles()
+}
+
+// getConfigFiles is like GetConfigFiles but the caller must hold c.mu.
+func (c *Config) getConfigFiles() []string {
 	paths := make([]string, 0)
 
 	for path := range slices.Values(c.paths) {
@@ -230,6 +347,8 @@ func (c *Config) GetConfigFiles() []string {
 // The format will be used when no specific encoder/decoder is available for
 // a requested format. Typical formats include "json", "yaml", "toml", etc.
 func (c *Config) SetFormat(f string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.defaultFormat = f
 }
 
@@ -237,7 +356,10 @@ func (c *Config) SetFormat(f string) {
 // These paths will be used when looking for configuration files to load.
 // Duplicate paths may be added.
 func (c *Config) AddPath(p string) {
+	c.mu.Lock()
 	c.paths = append(c.paths, p)
+	c.mu.Unlock()
+	c.watchNewPaths()
 }
 
 // AddFile adds a specific file path to the Config instance, marking it to be
@@ -245,13 +367,16 @@ func (c *Config) AddPath(p string) {
 // (to track specific files) and the general paths list (for search purposes).
 // This allows for both explicit file loading and path-based searching.
 func (c *Config) AddFile(p string) {
+	c.mu.Lock()
 	c.fullPath[p] = true
 	c.paths = append(c.paths, p)
+	c.mu.Unlock()
+	c.watchNewPaths()
 }
 
-// ReadConfig loads config files from GetConfigFiles(), following any "include"
-// directives to merge additional files recursively. Later values override
-// earlier ones.
+// ReadConfig loads every registered provider. Config files are loaded from
+// GetConfigFiles(), following any "include" directives to merge additional
+// files recursively. Later values override earlier ones.
 //
 // Example:
 //
@@ -264,39 +389,107 @@ func (c *Config) AddFile(p string) {
 //
 //	app.port = "8080"   // overridden by main.json
 //	app.env  = "prod"   // merged from a.yaml
+//
+// Errors of the providers are joined, each prefixed with the provider name.
+// Files that fail to load are skipped unless strict mode is set with SetStrict.
 func (c *Config) ReadConfig() error {
+	var errs []error
+	for e := range slices.Values(c.load().providers) {
+		if err := e.provider.Load(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.provider.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// readConfigFiles loads the config files into the snapshot. In strict mode
+// nothing is stored if any file fails.
+func (c *Config) readConfigFiles() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	config := map[string]any{}
-	paths := c.GetConfigFiles()
+	state := &loadState{origins: map[string][]string{}}
+	paths := c.getConfigFiles()
 	for path := range slices.Values(paths) {
-		visited := map[string]bool{}
-		m, err := c.readConfigFile(path, visited)
+		m, err := c.readConfigFile(path, state)
 		if err != nil {
-			if os.IsNotExist(err) {
+			if errors.Is(err, os.ErrNotExist) {
 				c.GetLogger().Debug("Config path doesn't exist", "path", path)
 			} else {
 				c.GetLogger().Warn("Failed to load config", "error", err)
+				state.errs = append(state.errs, err)
 			}
 			continue
 		}
 		DeepMerge(config, m)
 	}
-	c.config = config
+	if c.strict && len(state.errs) != 0 {
+		return errors.Join(state.errs...)
+	}
+	_ = c.updateLocked(func(s *snapshot) error {
+		s.config = config
+		s.files = state.files
+		s.origins = state.origins
+		return nil
+	})
 	if len(config) == 0 {
-		return errors.New("No configuration found")
+		return ErrNoConfig
 	}
 	return nil
 }
 
-func (c *Config) readConfigFile(path string, visited map[string]bool) (map[string]any, error) {
-	if visited[path] {
-		return nil, fmt.Errorf("cycle import detected: %s", path)
+// SetStrict enables or disables strict loading. By default ReadConfig logs
+// files and includes that fail to load, skips them and succeeds as long as
+// any configuration was found. In strict mode ReadConfig returns every
+// failure joined with errors.Join, as FileError, ParseError and CycleError
+// values, and keeps the previously loaded configuration.
+//
+// Example:
+//
+//	c.SetStrict(true)
+//	err := c.ReadConfig()
+//	var fe config.FileError
+//	if errors.As(err, &fe) {
+//		log.Printf("broken config file: %s", fe.Path)
+//	}
+func (c *Config) SetStrict(strict bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.strict = strict
+}
+
+// loadState tracks a single ReadConfig run across included files.
+type loadState struct {
+	// chain holds the files in the current include chain, outermost first.
+	chain []string
+	// files lists every file that was read, including included files.
+	files []string
+	// origins maps every key to the include chain of the file that set it.
+	origins map[string][]string
+	// errs collects the errors of failed includes.
+	errs []error
+}
+
+func (c *Config) readConfigFile(path string, state *loadState) (map[string]any, error) {
+	if slices.Contains(state.chain, path) {
+		return nil, CycleError{append(slices.Clone(state.chain), path)}
+	}
+	includedFrom := slices.Clone(state.chain)
+	state.chain = append(state.chain, path)
+	defer func() { state.chain = state.chain[:len(state.chain)-1] }()
+
+	if !slices.Contains(state.files, path) {
+		state.files = append(state.files, path)
 	}
-	visited[path] = true
-	defer delete(visited, path)
 
 	m, err := c.parse(path)
+	if pe, ok := err.(ParseError); ok {
+		pe.IncludedFrom = includedFrom
+		return nil, pe
+	}
 	if err != nil {
-		return nil, err
+		return nil, FileError{path, includedFrom, err}
 	}
 
 	base := map[string]any{}
@@ -306,18 +499,20 @@ func (c *Config) readConfigFile(path string, visited map[string]bool) (map[strin
 		delete(m, "include")
 		switch v := includeVal.(type) {
 		case string:
-			included, err := c.resolveInclude(dir, v, visited)
+			included, err := c.resolveInclude(dir, v, state)
 			if err != nil {
 				c.GetLogger().Warn("Failed to load included config", "path", v, "error", err)
+				state.errs = append(state.errs, err)
 			} else {
 				DeepMerge(base, included)
 			}
 		case []any:
 			for _, item := range v {
 				if inc, ok := item.(string); ok {
-					included, err := c.resolveInclude(dir, inc, visited)
+					included, err := c.resolveInclude(dir, inc, state)
 					if err != nil {
 						c.GetLogger().Warn("Failed to load included config", "path", inc, "error", err)
+						state.errs = append(state.errs, err)
 					} else {
 						DeepMerge(base, included)
 					}
@@ -326,16 +521,21 @@ func (c *Config) readConfigFile(path string, visited map[string]bool) (map[strin
 		}
 	}
 
+	chain := slices.Clone(state.chain)
+	walkKeys(m, nil, func(parts []KeyPart, _ any) {
+		state.origins[formatKey(parts)] = chain
+	})
+
 	DeepMerge(base, m)
 	return base, nil
 }
 
-func (c *Config) resolveInclude(baseDir, include string, visited map[string]bool) (map[string]any, error) {
+func (c *Config) resolveInclude(baseDir, include string, state *loadState) (map[string]any, error) {
 	includePath, err := FindPath(baseDir, include)
 	if err != nil {
-		return nil, err
+		return nil, FileError{include, slices.Clone(state.chain), err}
 	}
-	return c.readConfigFile(includePath, visited)
+	return c.readConfigFile(includePath, state)
 }
 
 func (c *Config) parse(path string) (m map[string]any, err error) {
@@ -360,25 +560,52 @@ func (c *Config) parse(path string) (m map[string]any, err error) {
 
 	m, err = decoder(b)
 	if err != nil {
-		return m, fmt.Errorf("%s: %v", ext, err)
+		pe := newParseError(ext, b, err)
+		pe.Path = path
+		return m, pe
 	}
 	return m, nil
 }
 
 // Set sets a value in the configuration under the specified key.
 func (c *Config) Set(key string, v any) error {
-	return c.setValue(&c.config, key, v)
+	if c.parent != nil {
+		key, err := c.parentKey(key)
+		if err != nil {
+			return err
+		}
+		return c.parent.Set(key, v)
+	}
+	return c.update(func(s *snapshot) error {
+		if err := c.setValue(&s.config, key, v); err != nil {
+			return err
+		}
+		s.origins = setOrigin(s.origins, key)
+		return nil
+	})
 }
 
 // SetDefault sets a value in the configuration's default values under the
 // specified key.
 func (c *Config) SetDefault(key string, v any) error {
-	return c.setValue(&c.defaults, key, v)
+	if c.parent != nil {
+		key, err := c.parentKey(key)
+		if err != nil {
+			return err
+		}
+		return c.parent.SetDefault(key, v)
+	}
+	return c.update(func(s *snapshot) error {
+		return c.setValue(&s.defaults, key, v)
+	})
 }
 
 // setValue sets a value in the provided map for a specific key. Nested keys
-// can be specified using dot notation (e.g., "database.host"). If the key is
-// ".", the entire map is replaced with the provided value.
+// can be specified using dot notation (e.g., "database.host") and slice
+// elements with an index (e.g., "servers.0.host" or "servers[0].host"). If the
+// key is ".", the entire map is replaced with the provided value.
+//
+// The map is not modified; *in is replaced by an updated copy.
 func (c *Config) setValue(in *map[string]any, key string, v any) error {
 	if key == "." {
 		vm, ok := v.(map[string]any)
@@ -386,6 +613,7 @@ func (c *Config) setValue(in *map[string]any, key string, v any) error {
 			return errors.New("global config must be a map[string]any")
 		}
 		*in = vm
+		return nil
 	}
 
 	parsed, err := KeySplit(key)
@@ -393,44 +621,86 @@ func (c *Config) setValue(in *map[string]any, key string, v any) error {
 		return err
 	}
 
-	m := *in
-	for i := range parsed.LastIndex() {
-		part := parsed.Parts[i].String()
-		if next, ok := m[part]; ok {
-			if subMap, ok := next.(map[string]any); ok {
-				m = subMap
-			} else {
-				// Overwrite non-map value with a new map
-				newMap := map[string]any{}
-				m[part] = newMap
-				m = newMap
+	out, err := setPath(*in, parsed.Parts, v)
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", key, err)
+	}
+	m, ok := out.(map[string]any)
+	if !ok {
+		return errors.New("global config must be a map[string]any")
+	}
+	*in = m
+	return nil
+}
+
+// setPath returns a copy of cur with v set at parts. Only the maps and slices
+// along the path are copied; cur itself is never modified. An index part
+// addresses an element of an existing slice, or appends to it when it equals
+// the length; index 0 starts a new slice in place of a missing or non-container
+// value. Other index parts, like "404" in "errors.404", are map keys. Indexes
+// past the end of a slice are an error, so slices never hold gaps.
+func setPath(cur any, parts []KeyPart, v any) (any, error) {
+	if len(parts) == 0 {
+		return v, nil
+	}
+	part := parts[0]
+
+	if part.Kind == IndexKey {
+		s, isSlice := cur.([]any)
+		_, isMap := cur.(map[string]any)
+		switch index := part.Int(); {
+		case isSlice && index > len(s):
+			return nil, fmt.Errorf("index %d is out of range for a list of %d elements", index, len(s))
+		case isSlice:
+			s = slices.Clone(s)
+			if index == len(s) {
+				s = append(s, nil)
+			}
+			elem, err := setPath(s[index], parts[1:], v)
+			if err != nil {
+				return nil, err
+			}
+			s[index] = elem
+			return s, nil
+		case !isMap && index == 0:
+			elem, err := setPath(nil, parts[1:], v)
+			if err != nil {
+				return nil, err
 			}
-		} else {
-			newMap := map[string]any{}
-			m[part] = newMap
-			m = newMap
+			return []any{elem}, nil
 		}
 	}
 
-	m[parsed.Parts[parsed.LastIndex()].String()] = v
-	return nil
+	m, ok := cur.(map[string]any)
+	if ok {
+		m = maps.Clone(m)
+	}
+	if m == nil {
+		// Overwrite non-map value with a new map
+		m = map[string]any{}
+	}
+	name := part.String()
+	elem, err := setPath(m[name], parts[1:], v)
+	if err != nil {
+		return nil, err
+	}
+	m[name] = elem
+	return m, nil
 }
 
 // Keys returns top-level keys of config
 func (c *Config) Keys() []string {
-	if c.config == nil {
-		return make([]string, 0)
-	}
-	keys := make([]string, 0, len(c.config))
-	for k := range c.config {
+	config := c.fileSettings()
+	keys := make([]string, 0, len(config))
+	for k := range config {
 		keys = append(keys, k)
 	}
 	return keys
 }
 
-// Settings returns the settings map
+// Settings returns a copy of the settings map
 func (c *Config) Settings() map[string]any {
-	return c.config
+	return deepCopy(c.fileSettings()).(map[string]any)
 }
 
 // Changed checks if a value is changed.
@@ -440,73 +710,97 @@ func (c *Config) Changed(key string) bool {
 }
 
 // GetE returns the value for the key, or error if missing/invalid.
+//
+// The registered providers are asked in order of priority; by default flags,
+// then environment variables, then config files and finally defaults.
 func (c *Config) GetE(key string) (any, error) {
-	if c.pflags != nil {
-		if flag, ok := c.pflags[key]; ok && flag.Changed {
-			return flag.Value.String(), nil
-		}
-	}
-
-	if c.pflagSet != nil && c.pflagSet.Parsed() && c.pflagSet.Changed(key) {
-		return c.pflagSet.Lookup(key).Value.String(), nil
-	}
-
 	parsed, err := KeySplit(key)
 	if err != nil {
 		return nil, err
 	}
 
-	env := parsed.EnvKey(c.envPrefix)
-	if v, ok := os.LookupEnv(env); ok {
-		return v, nil
-	}
-	c.GetLogger().Debug("Couldn't find value in env", "env_name", env, "error", err)
-
-	v, err := c.getValue(c.config, parsed)
-	if err != nil {
-		c.GetLogger().Debug("Failed to find value", "key", key, "error", err)
-		v, err := c.getValue(c.defaults, parsed)
-		if err == nil {
+	for e := range slices.Values(c.load().providers) {
+		if v, ok := e.provider.Lookup(parsed); ok {
 			return v, nil
 		}
-		c.GetLogger().Debug("Failed to find default value", "key", key, "error", err)
+		c.GetLogger().Debug("Couldn't find value", "key", key, "provider", e.provider.Name())
 	}
-	return v, err
+
+	return nil, KeyError{key}
 }
 
-// GetE returns the value for the key, or an error if missing/invalid.
+// getValue returns the value for the key in m, or an error if missing/invalid.
 func (c *Config) getValue(m map[string]any, key Key) (any, error) {
 	if key.Raw == "." {
 		return m, nil
 	}
 
 	var prefix strings.Builder
+	var cur any = m
 
-	for i := range key.LastIndex() {
-		part := key.Parts[i].String()
-		prefix.WriteString(part)
+	for i, part := range key.Parts {
+		if i > 0 {
+			prefix.WriteByte('.')
+		}
+		prefix.WriteString(part.String())
 
-		next, ok := m[part]
-		if !ok {
+		next, err := lookupPart(cur, part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid type for key: %s (%v)", prefix.String(), err)
+		}
+		if next == nil {
 			return nil, KeyError{prefix.String()}
 		}
+		cur = *next
+	}
 
-		subMap, ok := next.(map[string]any)
+	return cur, nil
+}
+
+// lookupPart returns a pointer to the child of cur addressed by part, or nil if
+// it doesn't exist. Maps are indexed by name and slices by index.
+func lookupPart(cur any, part KeyPart) (*any, error) {
+	switch v := cur.(type) {
+	case map[string]any:
+		val, ok := v[part.String()]
 		if !ok {
-			return nil, fmt.Errorf("invalid type for key: %s (expected map)",
-				prefix.String())
+			return nil, nil
 		}
-		m = subMap
-
-		prefix.WriteByte('.')
+		return &val, nil
+	case []any:
+		if part.Kind != IndexKey {
+			return nil, errors.New("expected map")
+		}
+		if part.Int() >= len(v) {
+			return nil, nil
+		}
+		return &v[part.Int()], nil
 	}
 
-	val, ok := m[key.Parts[key.LastIndex()].String()]
-	if !ok {
-		return nil, KeyError{prefix.String()}
+	rv := reflect.ValueOf(cur)
+	switch {
+	case isStringKeyMap(rv):
+		val := rv.MapIndex(reflect.ValueOf(part.String()).Convert(rv.Type().Key()))
+		if !val.IsValid() {
+			return nil, nil
+		}
+		out := val.Interface()
+		return &out, nil
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array:
+		if part.Kind != IndexKey {
+			return nil, errors.New("expected map")
+		}
+		if part.Int() >= rv.Len() {
+			return nil, nil
+		}
+		out := rv.Index(part.Int()).Interface()
+		return &out, nil
 	}
 
-	return val, nil
+	if part.Kind == IndexKey {
+		return nil, errors.New("expected map or slice")
+	}
+	return nil, errors.New("expected map")
 }
 
 // GetReflectionE returns the reflect.Value for the key, or error if missing/invalid.
@@ -180,6 +180,77 @@ func TestConfig(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "index keys resolve slice elements",
+			setup: func() *config.Config {
+				c := config.New()
+				c.Set("servers", []any{
+					map[string]any{"host": "a.example.com", "port": 80},
+					map[string]any{"host": "b.example.com", "port": 443},
+				})
+				return c
+			},
+			validate: func(t *testing.T, c *config.Config) {
+				if host := c.GetStringMust("servers.1.host"); host != "b.example.com" {
+					t.Fatalf("c.GetStringMust(\"servers.1.host\") = %v, want = %v", host, "b.example.com")
+				}
+				if port := c.GetIntMust("servers[0].port"); port != 80 {
+					t.Fatalf("c.GetIntMust(\"servers[0].port\") = %v, want = %v", port, 80)
+				}
+				if _, err := c.GetE("servers.2.host"); err == nil {
+					t.Fatal("c.GetE(\"servers.2.host\") should fail for out of range index")
+				}
+			},
+		},
+		{
+			name: "set with index creates and extends slices",
+			setup: func() *config.Config {
+				c := config.New()
+				c.Set("servers[0].host", "a.example.com")
+				c.Set("servers.1.host", "b.example.com")
+				c.Set("servers.1.port", 8080)
+				c.SetDefault("ports[0]", 8080)
+				return c
+			},
+			validate: func(t *testing.T, c *config.Config) {
+				servers, ok := c.GetMust("servers").([]any)
+				if !ok || len(servers) != 2 {
+					t.Fatalf("c.GetMust(\"servers\") = %v, want slice of length 2", c.GetMust("servers"))
+				}
+				if host := c.GetStringMust("servers.1.host"); host != "b.example.com" {
+					t.Fatalf("c.GetStringMust(\"servers.1.host\") = %v, want = %v", host, "b.example.com")
+				}
+				if port := c.GetIntMust("ports.0"); port != 8080 {
+					t.Fatalf("c.GetIntMust(\"ports.0\") = %v, want = %v", port, 8080)
+				}
+				if err := c.Set("servers.5.host", "f.example.com"); err == nil {
+					t.Fatal("c.Set(\"servers.5.host\") should fail for an index past the end")
+				}
+			},
+		},
+		{
+			name: "numeric keys without a list are map keys",
+			setup: func() *config.Config {
+				c := config.New()
+				c.Set("errors.404", "not found")
+				c.Set("errors[500]", "internal")
+				c.Set("huge.99999999999", true)
+				return c
+			},
+			validate: func(t *testing.T, c *config.Config) {
+				errs, ok := c.GetMust("errors").(map[string]any)
+				want := map[string]any{"404": "not found", "500": "internal"}
+				if !ok || !reflect.DeepEqual(errs, want) {
+					t.Fatalf("c.GetMust(\"errors\") = %#v, want = %#v", c.GetMust("errors"), want)
+				}
+				if msg := c.GetStringMust("errors.404"); msg != "not found" {
+					t.Fatalf("c.GetStringMust(\"errors.404\") = %v, want = %v", msg, "not found")
+				}
+				if !c.GetBoolMust("huge.99999999999") {
+					t.Fatal("c.GetBoolMust(\"huge.99999999999\") = false, want true")
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// crossFieldRules are the rules that compare a field with other fields of its
+// struct. Their parameters name the other fields by their Go names; fields of
+// nested structs are named with dots, such as "TLS.Enabled".
+//
+//   - required_if=Field value [Field value...]: Field must be set if every
+//     listed field has the given value.
+//   - required_unless=Field value [Field value...]: Field must be set unless
+//     every listed field has the given value.
+//   - required_with=Field [Field...]: Field must be set if any listed field is
+//     set.
+//   - required_without=Field [Field...]: Field must be set if any listed field
+//     is not set.
+//   - excluded_with=Field [Field...]: Field must not be set if any listed
+//     field is set.
+//   - excluded_without=Field [Field...]: Field must not be set if any listed
+//     field is not set.
+//   - eqfield, nefield=Field: Field must equal, or differ from, the other field.
+//   - gtfield, gtefield, ltfield, ltefield=Field: Field must be greater than,
+//     at least, less than or at most the other field. Numbers, strings,
+//     durations and times can be compared. The rule is skipped if the field is
+//     not set.
+//
+// The field itself is set if it was changed or isn't zero; the other fields
+// are set if they aren't zero. Values are compared with the values of other
+// fields in their fmt.Sprint form, so `required_if=Enabled true` matches a
+// true bool.
+var crossFieldRules = map[string]bool{
+	"required_if":      true,
+	"required_unless":  true,
+	"required_with":    true,
+	"required_without": true,
+	"excluded_with":    true,
+	"excluded_without": true,
+	"eqfield":          true,
+	"nefield":          true,
+	"gtfield":          true,
+	"gtefield":         true,
+	"ltfield":          true,
+	"ltefield":         true,
+}
+
+// siblingField returns the field of ctx.Parent with the given name. Nil
+// pointers on the way to the field resolve to zero values.
+func siblingField(ctx RuleContext, name string) (reflect.Value, error) {
+	if !ctx.Parent.IsValid() {
+		return reflect.Value{}, tagErrorf("field %q is unknown without the parent struct", name)
+	}
+
+	v := ctx.Parent
+	for part := range strings.SplitSeq(name, ".") {
+		v = indirectZero(v)
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, tagErrorf("unknown field %q", name)
+		}
+		sf, ok := v.Type().FieldByName(part)
+		if !ok || !sf.IsExported() {
+			return reflect.Value{}, tagErrorf("unknown field %q", name)
+		}
+		field, err := v.FieldByIndexErr(sf.Index)
+		if err != nil {
+			field = reflect.Zero(sf.Type)
+		}
+		v = field
+	}
+	return indirectZero(v), nil
+}
+
+// indirectZero follows pointers, resolving nil pointers to zero values.
+func indirectZero(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v = reflect.Zero(v.Type().Elem())
+		} else {
+			v = v.Elem()
+		}
+	}
+	return v
+}
+
+// isFieldSet reports whether the field being validated is set: changed by the
+// configuration or not zero, like a field with a default.
+func isFieldSet(ctx RuleContext) bool {
+	return ctx.Changed || !ctx.Value.IsZero()
+}
+
+// validateRequiredIf applies the required_* and excluded_* rules.
+func validateRequiredIf(ctx RuleContext, name string) error {
+	params := strings.Fields(ctx.Param)
+	if len(params) == 0 {
+		return tagErrorf("%s needs at least one field", name)
+	}
+
+	var cond bool
+	var reason string
+	switch name {
+	case "required_if", "required_unless":
+		if len(params)%2 != 0 {
+			return tagErrorf("%s needs pairs of fields and values", name)
+		}
+		cond = true
+		conds := make([]string, 0, len(params)/2)
+		for i := 0; i < len(params); i += 2 {
+			other, err := siblingField(ctx, params[i])
+			if err != nil {
+				return err
+			}
+			cond = cond && fmt.Sprint(other.Interface()) == params[i+1]
+			conds = append(conds, params[i]+" is "+params[i+1])
+		}
+		reason = strings.Join(conds, " and ")
+		if name == "required_if" {
+			reason = "when " + reason
+		} else {
+			cond = !cond
+			reason = "unless " + reason
+		}
+	default:
+		without := strings.HasSuffix(name, "_without")
+		for field := range slices.Values(params) {
+			other, err := siblingField(ctx, field)
+			if err != nil {
+				return err
+			}
+			cond = cond || other.IsZero() == without
+		}
+		reason = "when " + strings.Join(params, " or ") + " is set"
+		if without {
+			reason = "when " + strings.Join(params, " or ") + " is not set"
+		}
+	}
+
+	switch {
+	case !cond:
+		return nil
+	case strings.HasPrefix(name, "required") && !isFieldSet(ctx):
+		return fmt.Errorf("value is required %s", reason)
+	case strings.HasPrefix(name, "excluded") && isFieldSet(ctx):
+		return fmt.Errorf("value must not be set %s", reason)
+	}
+	return nil
+}
+
+// validateFieldComparison applies the eqfield, nefield, gtfield, gtefield,
+// ltfield and ltefield rules.
+func validateFieldComparison(ctx RuleContext, name string) error {
+	param := strings.TrimSpace(ctx.Param)
+	if param == "" {
+		return tagErrorf("%s needs a field", name)
+	}
+	other, err := siblingField(ctx, param)
+	if err != nil {
+		return err
+	}
+
+	value := indirectZero(ctx.Value)
+	order, err := compareValues(value, other)
+	if err != nil {
+		// Values that can't be ordered may still be checked for equality
+		if (name != "eqfield" && name != "nefield") || value.Type() != other.Type() {
+			return err
+		}
+		order = 1
+		if reflect.DeepEqual(value.Interface(), other.Interface()) {
+			order = 0
+		}
+	}
+	if !isFieldSet(ctx) {
+		return nil
+	}
+
+	var ok bool
+	var want string
+	switch name {
+	case "eqfield":
+		ok, want = order == 0, "equal"
+	case "nefield":
+		ok, want = order != 0, "differ from"
+	case "gtfield":
+		ok, want = order > 0, "be greater than"
+	case "gtefield":
+		ok, want = order >= 0, "be at least"
+	case "ltfield":
+		ok, want = order < 0, "be less than"
+	case "ltefield":
+		ok, want = order <= 0, "be at most"
+	}
+	if !ok {
+		return fmt.Errorf("value %v must %s %s (%v)", value.Interface(), want, param, other.Interface())
+	}
+	return nil
+}
+
+// compareValues compares two numbers, strings, durations or times, returning
+// -1, 0 or +1 like cmp.Compare.
+func compareValues(a, b reflect.Value) (int, error) {
+	timeType := reflect.TypeFor[time.Time]()
+	switch {
+	case a.Type() == timeType && b.Type() == timeType:
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time)), nil
+	case a.CanInt() && b.CanInt():
+		return cmp.Compare(a.Int(), b.Int()), nil
+	case a.CanUint() && b.CanUint():
+		return cmp.Compare(a.Uint(), b.Uint()), nil
+	case isNumber(a) && isNumber(b):
+		return cmp.Compare(toFloat(a), toFloat(b)), nil
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return cmp.Compare(a.String(), b.String()), nil
+	}
+	return 0, tagErrorf("can't compare %s with %s", a.Type(), b.Type())
+}
+
+// isNumber reports whether v is an integer, an unsigned integer or a float.
+func isNumber(v reflect.Value) bool {
+	return v.CanInt() || v.CanUint() || v.CanFloat()
+}
+
+// toFloat returns the number v as a float64.
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
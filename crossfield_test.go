@@ -0,0 +1,171 @@
+package config_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nadim147c/go-config"
+)
+
+type crossTLS struct {
+	Enabled bool   `config:"enabled"`
+	Cert    string `config:"cert" check:"required_if=Enabled true"`
+	Key     string `config:"key" check:"required_with=Cert"`
+}
+
+type crossApp struct {
+	Mode     string        `config:"mode"`
+	TLS      crossTLS      `config:"tls"`
+	Port     int           `config:"port" check:"required_unless=Mode dev"`
+	MinConns int           `config:"minConns"`
+	MaxConns int           `config:"maxConns" check:"gtefield=MinConns"`
+	Password string        `config:"password"`
+	Confirm  string        `config:"confirm" check:"eqfield=Password"`
+	Token    string        `config:"token" check:"excluded_with=Password"`
+	User     string        `config:"user" check:"required_without=Token"`
+	Timeout  time.Duration `config:"timeout" check:"ltfield=Deadline"`
+	Deadline time.Duration `config:"deadline"`
+	Cert     string        `config:"cert" check:"required_if=TLS.Enabled true"`
+}
+
+func TestCrossFieldRules(t *testing.T) {
+	base := map[string]any{
+		"app.mode":        "prod",
+		"app.port":        8080,
+		"app.user":        "admin",
+		"app.tls.enabled": true,
+		"app.tls.cert":    "cert.pem",
+		"app.tls.key":     "key.pem",
+		"app.cert":        "cert.pem",
+		"app.minConns":    2,
+		"app.maxConns":    10,
+		"app.password":    "secret",
+		"app.confirm":     "secret",
+		"app.timeout":     "1s",
+		"app.deadline":    "5s",
+	}
+
+	tests := []struct {
+		name   string
+		values map[string]any
+		want   map[string]string
+	}{
+		{name: "valid"},
+		{
+			name:   "required_if",
+			values: map[string]any{"app.tls.cert": nil, "app.tls.key": nil},
+			want:   map[string]string{"app.tls.cert": "required_if"},
+		},
+		{
+			name:   "required_if on a nested field",
+			values: map[string]any{"app.cert": nil},
+			want:   map[string]string{"app.cert": "required_if"},
+		},
+		{
+			name:   "required_if condition unmet",
+			values: map[string]any{"app.tls.enabled": false, "app.tls.cert": nil, "app.tls.key": nil, "app.cert": nil},
+		},
+		{
+			name:   "required_with",
+			values: map[string]any{"app.tls.key": nil},
+			want:   map[string]string{"app.tls.key": "required_with"},
+		},
+		{
+			name:   "required_unless",
+			values: map[string]any{"app.port": nil},
+			want:   map[string]string{"app.port": "required_unless"},
+		},
+		{
+			name:   "required_unless condition met",
+			values: map[string]any{"app.port": nil, "app.mode": "dev"},
+		},
+		{
+			name:   "gtefield",
+			values: map[string]any{"app.maxConns": 1},
+			want:   map[string]string{"app.maxConns": "gtefield"},
+		},
+		{
+			name:   "eqfield",
+			values: map[string]any{"app.confirm": "secert"},
+			want:   map[string]string{"app.confirm": "eqfield"},
+		},
+		{
+			name:   "excluded_with",
+			values: map[string]any{"app.token": "abc"},
+			want:   map[string]string{"app.token": "excluded_with"},
+		},
+		{
+			name:   "required_without",
+			values: map[string]any{"app.user": nil},
+			want:   map[string]string{"app.user": "required_without"},
+		},
+		{
+			name:   "ltfield on durations",
+			values: map[string]any{"app.timeout": "10s"},
+			want:   map[string]string{"app.timeout": "ltfield"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := config.New()
+			for key, value := range base {
+				if v, ok := tt.values[key]; ok {
+					if v == nil {
+						continue
+					}
+					value = v
+				}
+				c.Set(key, value)
+			}
+			for key, value := range tt.values {
+				if _, ok := base[key]; !ok && value != nil {
+					c.Set(key, value)
+				}
+			}
+
+			var app crossApp
+			err := c.Bind("app", &app)
+
+			got := map[string]string{}
+			var errs config.BindErrors
+			if errors.As(err, &errs) {
+				for _, e := range errs {
+					got[e.Key] = e.Rule
+				}
+			} else if err != nil {
+				t.Fatalf("Bind() error = %v", err)
+			}
+			if len(tt.want) == 0 && len(got) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Bind() errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrossFieldRuleTags(t *testing.T) {
+	type Invalid struct {
+		Name    string
+		Count   int
+		Missing string `check:"required_with=Nmae"`
+		Pairs   string `check:"required_if=Name"`
+		Types   int    `check:"gtfield=Name"`
+		Empty   string `check:"eqfield"`
+	}
+
+	err := config.ValidateTags(reflect.TypeFor[Invalid]())
+	if err == nil {
+		t.Fatal("ValidateTags() should fail")
+	}
+	for field := range strings.SplitSeq("Missing Pairs Types Empty", " ") {
+		if !strings.Contains(err.Error(), "on "+field+":") {
+			t.Errorf("ValidateTags() error = %v, want %s", err, field)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// diveRules holds the rules of a "check" tag after "dive".
+type diveRules struct {
+	// keys are the rules between "keys" and "endkeys", applied to map keys.
+	keys string
+	// elems are the rules applied to the elements.
+	elems string
+}
+
+// splitDive splits a "check" tag at its first "dive". head holds the rules of
+// the field itself; dive is nil if the tag has no "dive".
+func splitDive(tag string) (head string, dive *diveRules, err error) {
+	parts, err := splitCSVRespectQuotes(tag)
+	if err != nil {
+		return "", nil, err
+	}
+	is := func(name string) func(string) bool {
+		return func(part string) bool { return strings.TrimSpace(part) == name }
+	}
+
+	i := slices.IndexFunc(parts, is("dive"))
+	if i < 0 {
+		return tag, nil, nil
+	}
+	dive = &diveRules{}
+	rest := parts[i+1:]
+	if len(rest) != 0 && is("keys")(rest[0]) {
+		end := slices.IndexFunc(rest, is("endkeys"))
+		if end < 0 {
+			return "", nil, errors.New("keys without endkeys")
+		}
+		dive.keys = strings.Join(rest[1:end], ",")
+		rest = rest[end+1:]
+	}
+	dive.elems = strings.Join(rest, ",")
+	return strings.Join(parts[:i], ","), dive, nil
+}
+
+// elementError is an error of an element checked with the rules after "dive".
+type elementError struct {
+	// key is the key of the element, such as "hosts.2".
+	key string
+	// typ is the type of the element.
+	typ reflect.Type
+	err error
+}
+
+func (ee elementError) Error() string {
+	return fmt.Sprintf("%s: %v", ee.key, ee.err)
+}
+
+func (ee elementError) Unwrap() error {
+	return ee.err
+}
+
+// validateDive applies the rules after "dive" to the elements of ctx.Value and
+// returns the error of the first invalid element.
+func validateDive(ctx RuleContext, dive *diveRules, cross bool) error {
+	v := resolvePointer(ctx.Value)
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if dive.keys != "" {
+			return diveTagError(ctx, tagErrorf("keys can't be used on %s", v.Type()))
+		}
+		if v.Len() == 0 {
+			return validateZeroElement(ctx, v.Type().Elem(), dive.elems, cross)
+		}
+		for i := range v.Len() {
+			if err := validateElement(ctx, v.Index(i), dive.elems, strconv.Itoa(i), cross); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.Len() == 0 {
+			if err := validateZeroElement(ctx, v.Type().Key(), dive.keys, cross); err != nil {
+				return err
+			}
+			return validateZeroElement(ctx, v.Type().Elem(), dive.elems, cross)
+		}
+		return updateMapElements(v, func(k reflect.Value, name string, elem reflect.Value) error {
+			key := reflect.New(k.Type()).Elem()
+			key.Set(k)
+			if err := validateElement(ctx, key, dive.keys, name, cross); err != nil {
+				return err
+			}
+			return validateElement(ctx, elem, dive.elems, name, cross)
+		})
+	default:
+		return diveTagError(ctx, tagErrorf("dive can't be used on %s", v.Type()))
+	}
+	return nil
+}
+
+// updateMapElements calls fn with each key of the map m, its name and a copy
+// of its element, in the order of the names, and stores the copy back unless
+// fn fails. Map elements aren't settable, so rules that normalize values work
+// on the copy.
+func updateMapElements(m reflect.Value, fn func(k reflect.Value, name string, elem reflect.Value) error) error {
+	keys := m.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return cmp.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+	})
+	for k := range slices.Values(keys) {
+		elem := reflect.New(m.Type().Elem()).Elem()
+		elem.Set(m.MapIndex(k))
+		if err := fn(k, fmt.Sprint(k.Interface()), elem); err != nil {
+			return err
+		}
+		m.SetMapIndex(k, elem)
+	}
+	return nil
+}
+
+// validateElement applies rules to elem, the element of ctx.Value with the
+// given name.
+func validateElement(ctx RuleContext, elem reflect.Value, rules, name string, cross bool) error {
+	if rules == "" {
+		return nil
+	}
+
+	key := ctx.Key
+	if key == "" {
+		key = ctx.Field.Name
+	}
+	ectx := ctx
+	ectx.Field = reflect.StructField{
+		Name: ctx.Field.Name,
+		Type: elem.Type(),
+		Tag:  reflect.StructTag("check:" + strconv.Quote(rules)),
+	}
+	ectx.Value = elem
+	ectx.Key = key + "." + name
+	ectx.Changed = true
+
+	err := validateField(ectx, cross)
+	var te TagError
+	var ee elementError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &te):
+		return diveTagError(ctx, err)
+	case errors.As(err, &ee):
+		return err
+	}
+	return elementError{ectx.Key, elem.Type(), err}
+}
+
+// validateZeroElement reports the problems of the rules for elements of type t
+// when there are no elements to apply them to, so ValidateTags finds them.
+func validateZeroElement(ctx RuleContext, t reflect.Type, rules string, cross bool) error {
+	err := validateElement(ctx, newValue(t), rules, "0", cross)
+	if te := (TagError{}); errors.As(err, &te) {
+		return err
+	}
+	return nil
+}
+
+// diveTagError returns the TagError in err with the field and the tag of
+// ctx.Field.
+func diveTagError(ctx RuleContext, err error) error {
+	var te TagError
+	if !errors.As(err, &te) {
+		return err
+	}
+	te.Field, te.Tag = ctx.Field.Name, ctx.Field.Tag.Get("check")
+	return ruleError{te.Rule, te}
+}
@@ -0,0 +1,121 @@
+package config_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+type diveApp struct {
+	Admins  []string       `config:"admins" check:"min=1,dive,email"`
+	Weights map[string]int `config:"weights" check:"dive,keys,alpha,endkeys,min=1,max=100"`
+	Groups  [][]string     `config:"groups" check:"dive,min=1,dive,alphanumeric"`
+}
+
+func TestDive(t *testing.T) {
+	base := map[string]any{
+		"app.admins":  []any{"a@example.com", "b@example.com"},
+		"app.weights": map[string]any{"east": 10, "west": 90},
+		"app.groups":  []any{[]any{"a", "b"}, []any{"c"}},
+	}
+
+	tests := []struct {
+		name     string
+		values   map[string]any
+		wantKey  string
+		wantRule string
+	}{
+		{name: "valid"},
+		{
+			name:     "rule before dive",
+			values:   map[string]any{"app.admins": []any{}},
+			wantKey:  "app.admins",
+			wantRule: "min",
+		},
+		{
+			name:     "slice element",
+			values:   map[string]any{"app.admins": []any{"a@example.com", "not-an-email"}},
+			wantKey:  "app.admins.1",
+			wantRule: "email",
+		},
+		{
+			name:     "map value",
+			values:   map[string]any{"app.weights": map[string]any{"east": 10, "west": 900}},
+			wantKey:  "app.weights.west",
+			wantRule: "max",
+		},
+		{
+			name:     "map key",
+			values:   map[string]any{"app.weights": map[string]any{"east": 10, "us-west": 90}},
+			wantKey:  "app.weights.us-west",
+			wantRule: "alpha",
+		},
+		{
+			name:     "nested dive",
+			values:   map[string]any{"app.groups": []any{[]any{"a"}, []any{"b", "c-d"}}},
+			wantKey:  "app.groups.1.1",
+			wantRule: "alphanumeric",
+		},
+		{
+			name:     "nested dive length",
+			values:   map[string]any{"app.groups": []any{[]any{"a"}, []any{}}},
+			wantKey:  "app.groups.1",
+			wantRule: "min",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := config.New()
+			for key, value := range base {
+				if v, ok := tt.values[key]; ok {
+					value = v
+				}
+				c.Set(key, value)
+			}
+
+			var app diveApp
+			err := c.Bind("app", &app)
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Errorf("Bind() error = %v", err)
+				}
+				return
+			}
+
+			var errs config.BindErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Bind() error = %v, want BindErrors", err)
+			}
+			if len(errs) != 1 || errs[0].Key != tt.wantKey || errs[0].Rule != tt.wantRule {
+				t.Errorf("Bind() error = %v, want %s: %s", err, tt.wantKey, tt.wantRule)
+			}
+			if !strings.HasPrefix(err.Error(), tt.wantKey+": ") {
+				t.Errorf("Bind() error = %q, want it to name %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestDiveTags(t *testing.T) {
+	type Invalid struct {
+		Port    int               `check:"dive,min=1"`
+		List    []string          `check:"dive,keys,alpha,endkeys"`
+		Unended map[string]string `check:"dive,keys,alpha"`
+		Elem    []int             `check:"dive,email"`
+		Key     map[int]string    `check:"dive,keys,email,endkeys"`
+	}
+
+	err := config.ValidateTags(reflect.TypeFor[Invalid]())
+	if err == nil {
+		t.Fatal("ValidateTags() should fail")
+	}
+	for field := range strings.SplitSeq("Port List Unended Elem Key", " ") {
+		if !strings.Contains(err.Error(), "on "+field+":") {
+			t.Errorf("ValidateTags() error = %v, want %s", err, field)
+		}
+	}
+}
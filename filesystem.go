@@ -0,0 +1,103 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// baseDir returns the directory relative paths of the field resolve against:
+// the directory of the config file that set the field, or the working
+// directory for values from other sources.
+func (ctx RuleContext) baseDir() string {
+	if ctx.config == nil || ctx.Key == "" {
+		return ""
+	}
+	origin, err := ctx.config.Origin(ctx.Key)
+	if err != nil || origin.Layer != LayerFile || origin.Source == "" {
+		return ""
+	}
+	return filepath.Dir(origin.Source)
+}
+
+// findPath returns the absolute path of the path str of the field, expanded
+// with FindPath.
+func (ctx RuleContext) findPath(str string) (string, error) {
+	path, err := FindPath(ctx.baseDir(), str)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(path)
+}
+
+// validatePath applies the expand, abs, exists, file, dir, readable, writable
+// and executable rules to a string field holding a path. expand rewrites the
+// field to an absolute path, resolving relative paths like FindPath does,
+// against the directory of the config file that set them. The other rules
+// check the value as it is, so relative paths are resolved against the working
+// directory unless expand comes first.
+func validatePath(ctx RuleContext, name string) error {
+	value, err := stringValue(name, ctx.Value)
+	if err != nil || !value.IsValid() {
+		return err
+	}
+	str := value.String()
+
+	if name == "expand" {
+		if str == "" {
+			return nil
+		}
+		path, err := ctx.findPath(str)
+		if err != nil {
+			return fmt.Errorf("cannot expand %q: %w", str, err)
+		}
+		value.SetString(path)
+		return nil
+	}
+	if name == "abs" {
+		if !filepath.IsAbs(str) {
+			return fmt.Errorf("%q is not an absolute path", str)
+		}
+		return nil
+	}
+
+	if str == "" {
+		return errors.New("path is empty")
+	}
+	path := str
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s does not exist", path)
+		}
+		return err
+	}
+
+	switch name {
+	case "file":
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("%s is not a regular file", path)
+		}
+	case "dir":
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", path)
+		}
+	case "readable":
+		if err := canRead(path); err != nil {
+			return fmt.Errorf("%s is not readable: %w", path, err)
+		}
+	case "writable":
+		if err := canWrite(path, info.IsDir()); err != nil {
+			return fmt.Errorf("%s is not writable: %w", path, err)
+		}
+	case "executable":
+		if info.IsDir() {
+			return fmt.Errorf("%s is not executable", path)
+		}
+		if err := canExec(path, info); err != nil {
+			return fmt.Errorf("%s is not executable: %w", path, err)
+		}
+	}
+	return nil
+}
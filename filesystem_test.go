@@ -0,0 +1,132 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+func TestPathRules(t *testing.T) {
+	dir := t.TempDir()
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	must(os.MkdirAll(filepath.Join(dir, "certs"), 0o755))
+	must(os.WriteFile(filepath.Join(dir, "certs", "key.pem"), []byte("key"), 0o600))
+	must(os.WriteFile(filepath.Join(dir, "hook.sh"), []byte("#!/bin/sh\n"), 0o755))
+
+	file := filepath.Join(dir, "app.yaml")
+	must(os.WriteFile(file, []byte(strings.Join([]string{
+		"app:",
+		"  key: certs/key.pem",
+		"  expanded: certs/key.pem",
+		"  certs: certs",
+		"  hook: hook.sh",
+		"  root: " + dir,
+		"  missing: certs/missing.pem",
+		"  notdir: certs/key.pem",
+		"  relative: certs",
+		"  notexec: certs/key.pem",
+		"  unexpanded: certs/key.pem",
+	}, "\n")), 0o644))
+
+	type App struct {
+		Key        string `config:"key" check:"expand,file,readable,writable"`
+		Expanded   string `config:"expanded" check:"expand,abs,file"`
+		Certs      string `config:"certs" check:"expand,dir,readable,writable"`
+		Hook       string `config:"hook" check:"expand,executable"`
+		Root       string `config:"root" check:"abs,exists"`
+		Missing    string `config:"missing" check:"expand,exists"`
+		NotDir     string `config:"notdir" check:"expand,dir"`
+		Relative   string `config:"relative" check:"abs"`
+		NotExec    string `config:"notexec" check:"expand,executable"`
+		Unexpanded string `config:"unexpanded" check:"file"`
+	}
+
+	c := config.New()
+	c.AddFile(file)
+	if err := c.ReadConfig(); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+
+	var app App
+	err := c.Bind("app", &app)
+	var errs config.BindErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Bind() error = %v, want BindErrors", err)
+	}
+
+	got := map[string]string{}
+	for _, e := range errs {
+		got[e.Key] = e.Rule
+	}
+	want := map[string]string{
+		"app.missing":    "exists",
+		"app.notdir":     "dir",
+		"app.relative":   "abs",
+		"app.notexec":    "executable",
+		"app.unexpanded": "file",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Bind() errors = %v, want %v", got, want)
+	}
+
+	if want := filepath.Join(dir, "certs", "key.pem"); app.Expanded != want {
+		t.Errorf("Expanded = %q, want %q", app.Expanded, want)
+	}
+	if app.Unexpanded != "certs/key.pem" {
+		t.Errorf("Unexpanded = %q, want the value unchanged without expand", app.Unexpanded)
+	}
+}
+
+func TestPathRulesExpand(t *testing.T) {
+	type Paths struct {
+		Home  string `config:"home" check:"expand"`
+		Local string `config:"local" check:"expand"`
+		Empty string `config:"empty" check:"expand"`
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := config.New()
+	c.Set("paths.home", "~/certs/ca.pem")
+	c.Set("paths.local", "certs/ca.pem")
+	var paths Paths
+	if err := c.Bind("paths", &paths); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	want := Paths{
+		Home:  filepath.Join(home, "certs", "ca.pem"),
+		Local: filepath.Join(wd, "certs", "ca.pem"),
+	}
+	if paths != want {
+		t.Errorf("Bind() = %+v, want %+v", paths, want)
+	}
+
+	type Invalid struct {
+		Count int  `check:"file"`
+		Flag  bool `check:"expand"`
+	}
+	err = config.ValidateTags(reflect.TypeFor[Invalid]())
+	for field := range strings.SplitSeq("Count Flag", " ") {
+		if err == nil || !strings.Contains(err.Error(), "on "+field+":") {
+			t.Errorf("ValidateTags() error = %v, want %s", err, field)
+		}
+	}
+}
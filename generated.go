@@ -4,20 +4,23 @@ package config
 import (
 	"log/slog"
 	"reflect"
+	"time"
 
 	"github.com/spf13/pflag"
 )
 
 // Bind maps configuration values from the Config instance into a structured
 // Go type. It uses struct tags to determine how to bind the data and can also
-// perform validation.
+// perform validation. Structs implementing Validator or ValidatorWithConfig
+// are checked once their fields are bound and valid.
 //
 // Parameters:
 //   - prefix: The prefix to prepend to all configuration keys
 //   - v: A pointer to a struct where the configuration values will be populated
 //
 // Returns:
-//   - error: If the input is not a non-nil pointer, or if binding fails
+//   - error: If the input is not a non-nil pointer, or BindErrors listing
+//     every field that failed to bind or validate
 func Bind(prefix string, v any) error { return Default().Bind(prefix, v) }
 
 // SetPflagSet adds *pflag.FlagSet
@@ -63,9 +66,9 @@ func AddPath(p string) { Default().AddPath(p) }
 // This allows for both explicit file loading and path-based searching.
 func AddFile(p string) { Default().AddFile(p) }
 
-// ReadConfig loads config files from GetConfigFiles(), following any "include"
-// directives to merge additional files recursively. Later values override
-// earlier ones.
+// ReadConfig loads every registered provider. Config files are loaded from
+// GetConfigFiles(), following any "include" directives to merge additional
+// files recursively. Later values override earlier ones.
 //
 // Example:
 //
@@ -78,8 +81,27 @@ func AddFile(p string) { Default().AddFile(p) }
 //
 //	app.port = "8080"   // overridden by main.json
 //	app.env  = "prod"   // merged from a.yaml
+//
+// Errors of the providers are joined, each prefixed with the provider name.
+// Files that fail to load are skipped unless strict mode is set with SetStrict.
 func ReadConfig() error { return Default().ReadConfig() }
 
+// SetStrict enables or disables strict loading. By default ReadConfig logs
+// files and includes that fail to load, skips them and succeeds as long as
+// any configuration was found. In strict mode ReadConfig returns every
+// failure joined with errors.Join, as FileError, ParseError and CycleError
+// values, and keeps the previously loaded configuration.
+//
+// Example:
+//
+//	c.SetStrict(true)
+//	err := c.ReadConfig()
+//	var fe config.FileError
+//	if errors.As(err, &fe) {
+//		log.Printf("broken config file: %s", fe.Path)
+//	}
+func SetStrict(strict bool) { Default().SetStrict(strict) }
+
 // Set sets a value in the configuration under the specified key.
 func Set(key string, v any) error { return Default().Set(key, v) }
 
@@ -90,7 +112,7 @@ func SetDefault(key string, v any) error { return Default().SetDefault(key, v) }
 // Keys returns top-level keys of config
 func Keys() []string { return Default().Keys() }
 
-// Settings returns the settings map
+// Settings returns a copy of the settings map
 func Settings() map[string]any { return Default().Settings() }
 
 // Changed checks if a value is changed.
@@ -415,3 +437,149 @@ func GetStringMapStringSlice(key string) map[string][]string {
 func (c *Config) GetStringMapStringSlice(key string) map[string][]string {
 	return Should(c.GetStringMapStringSliceE(key))
 }
+
+// Origin returns which provider supplied the value GetE returns for the key.
+func Origin(key string) (Provenance, error) { return Default().Origin(key) }
+
+// Explain returns which provider supplied the value for the key and which
+// values of lower precedence it shadows.
+//
+// Example:
+//
+//	app.port = 9000 (from env APP_APP__PORT)
+//	  shadows 8080 (from file /etc/app/app.yaml (included from /etc/app/config.yaml))
+//	  shadows 80 (from defaults)
+func Explain(key string) (Explanation, error) { return Default().Explain(key) }
+
+// SetPrecedence sets the order in which the built-in layers are consulted,
+// from highest to lowest precedence. The default is
+//
+//	SetPrecedence(LayerFlags, LayerEnv, LayerFile, LayerDefaults)
+//
+// Layers that are left out are not consulted at all. The first layer gets
+// PriorityFlags, the second PriorityEnv and so on, each 100 lower than the
+// previous one, so custom providers keep their place between the slots.
+//
+// Example: make an explicit config file beat environment variables:
+//
+//	SetPrecedence(LayerFlags, LayerFile, LayerEnv, LayerDefaults)
+func SetPrecedence(layers ...Layer) error { return Default().SetPrecedence(layers...) }
+
+// AddProvider registers a custom provider at the given priority. Use the
+// Priority constants to place it relative to the built-in providers; e.g.
+// PriorityEnv+1 places a provider between flags and environment variables.
+// Providers with equal priority are asked in registration order.
+//
+// The provider is loaded by the next call to ReadConfig.
+func AddProvider(p Provider, priority int) { Default().AddProvider(p, priority) }
+
+// Query returns every concrete key matching pattern along with its value.
+// The pattern uses the KeySplit grammar, where each part may be a glob as
+// understood by path.Match and "**" matches zero or more parts.
+//
+// Candidate keys are those of AllKeys and their parents, and each value is
+// resolved with GetE, so the priorities of the providers apply.
+//
+// Example:
+//
+//	servers.*.host     → servers.0.host, servers.1.host
+//	plugins.**.enabled → plugins.enabled, plugins.a.enabled, plugins.a.b.enabled
+//	db_*               → db_host, db_port
+func Query(pattern string) (map[string]any, error) { return Default().Query(pattern) }
+
+// AllKeys returns the sorted leaf keys known to any provider, such as
+// "app.port" or "servers.0.host". Keys are collected from every provider
+// implementing KeyLister; the built-in providers list changed flags,
+// environment variables with the prefix set by SetEnvPrefix, config files and
+// defaults.
+//
+// An environment variable is listed under the key of another provider when
+// its name matches that key's EnvKey, so "APP_DBHOST" is listed as "dbHost"
+// if a config file sets "dbHost".
+func AllKeys() []string { return Default().AllKeys() }
+
+// AllSettings returns the fully resolved configuration as a nested map. Every
+// key of AllKeys is resolved with GetE, so the result holds exactly the values
+// the getters return, across flags, environment variables, config files and
+// defaults.
+//
+// Keys with an index part, like "servers.0.host", are stored in slices.
+func AllSettings() map[string]any { return Default().AllSettings() }
+
+// Sub returns a view of the configuration rooted at key, so that
+// c.Sub("plugins.cache").GetInt("size") returns c.GetInt("plugins.cache.size").
+// It returns nil if the key is invalid.
+//
+// The view is live: every provider of c is consulted with the key prefixed, so
+// flags, defaults and environment variables apply under their full names; with
+// the env prefix "APP", the view's key "size" is read from
+// APP_PLUGINS__CACHE__SIZE. Set and SetDefault on the view write to c.
+//
+// WriteConfigAs on the view writes the section of the config files and
+// defaults of c below the key. WriteConfig on the view returns an error.
+//
+// The providers of c are copied when Sub is called. Providers added to the
+// view, and SetPrecedence on the view, only affect the view. Files, flags and
+// the env prefix stay with c.
+func Sub(key string) *Config { return Default().Sub(key) }
+
+// BindStrict is like Bind but fails with an UnusedKeysError if the config files
+// or the environment have keys below the prefix that don't map to any field.
+// The fields are bound either way.
+func BindStrict(prefix string, v any) error { return Default().BindStrict(prefix, v) }
+
+// UnusedKeys returns the keys below the prefix that are set in config files,
+// with Set or in environment variables with the prefix of SetEnvPrefix, but
+// that Bind(prefix, v) wouldn't read into any field. Each key comes with the
+// closest key that maps to a field, if any, so typos are easy to spot:
+//
+//	databse.host → database.host
+//
+// v is a struct, a pointer to one or a reflect.Type. Keys below map, interface
+// and TextUnmarshaler fields are always used. Environment variables are
+// matched by their EnvKey, so APP_DATABASE__MAXCONNS is used by a field
+// tagged "maxConns".
+func UnusedKeys(v any, prefix string) []UnusedKey { return Default().UnusedKeys(v, prefix) }
+
+// OnConfigChange registers a callback that is called after WatchConfig
+// reloads the configuration.
+func OnConfigChange(fn func(Event)) { Default().OnConfigChange(fn) }
+
+// SetWatchDebounce sets how long WatchConfig waits for further changes before
+// reloading. Editors often write a file in several steps; the debounce groups
+// them into a single reload.
+func SetWatchDebounce(d time.Duration) { Default().SetWatchDebounce(d) }
+
+// WatchConfig watches every file returned by GetConfigFiles, every file pulled
+// in through "include" and every directory added with AddPath. When one of
+// them changes, ReadConfig is called again and the OnConfigChange callbacks
+// are fired. Paths added with AddPath or AddFile later are watched too.
+// Calling WatchConfig while already watching is a no-op.
+//
+// Parent directories are watched instead of the files themselves, so editors
+// that replace a file by renaming a new one over it are handled, and config
+// files created later in an AddPath directory are picked up.
+func WatchConfig() error { return Default().WatchConfig() }
+
+// StopWatchConfig stops the watcher started by WatchConfig. Pending reloads are
+// discarded.
+func StopWatchConfig() error { return Default().StopWatchConfig() }
+
+// WriteConfig writes the current settings to the last file returned by
+// GetConfigFiles, which is the file with the highest precedence. It returns an
+// error if no config file has been found, or for a view created by Sub, whose
+// section would replace the whole file.
+func WriteConfig() error { return Default().WriteConfig() }
+
+// WriteConfigAs writes the current settings (config merged with defaults) to
+// the given path, replacing the file if it already exists. The encoder is
+// chosen from the file extension, falling back to the default format.
+//
+// The file is written to a temporary file in the same directory and renamed
+// into place, so readers never observe a partially written config.
+func WriteConfigAs(path string) error { return Default().WriteConfigAs(path) }
+
+// SafeWriteConfigAs writes the current settings to the given path like
+// WriteConfigAs, but returns an error wrapping os.ErrExist instead of
+// replacing an existing file.
+func SafeWriteConfigAs(path string) error { return Default().SafeWriteConfigAs(path) }
@@ -39,6 +39,7 @@ func main() {
 import (
 	"log/slog"
 	"reflect"
+	"time"
 	"github.com/spf13/pflag"
 )
 `)
@@ -95,7 +96,11 @@ import (
 			for _, p := range fn.Type.Params.List {
 				for _, n := range p.Names {
 					params = append(params, n.Name+" "+exprToString(p.Type))
-					args = append(args, n.Name)
+					if _, variadic := p.Type.(*ast.Ellipsis); variadic {
+						args = append(args, n.Name+"...")
+					} else {
+						args = append(args, n.Name)
+					}
 				}
 			}
 			retTypes := []string{}
@@ -140,11 +145,42 @@ func exprToString(e ast.Expr) string {
 		return "[]" + exprToString(t.Elt)
 	case *ast.MapType:
 		return "map[" + exprToString(t.Key) + "]" + exprToString(t.Value)
+	case *ast.Ellipsis:
+		return "..." + exprToString(t.Elt)
+	case *ast.FuncType:
+		return "func(" + fieldListToString(t.Params) + ")" + resultsToString(t.Results)
 	default:
 		return "interface{}"
 	}
 }
 
+// fieldListToString returns the comma separated types of a parameter list
+func fieldListToString(fl *ast.FieldList) string {
+	if fl == nil {
+		return ""
+	}
+	types := []string{}
+	for _, f := range fl.List {
+		n := max(len(f.Names), 1)
+		for range n {
+			types = append(types, exprToString(f.Type))
+		}
+	}
+	return strings.Join(types, ", ")
+}
+
+// resultsToString returns the result list of a function type
+func resultsToString(fl *ast.FieldList) string {
+	if fl == nil || len(fl.List) == 0 {
+		return ""
+	}
+	types := fieldListToString(fl)
+	if len(fl.List) == 1 && len(fl.List[0].Names) <= 1 {
+		return " " + types
+	}
+	return " (" + types + ")"
+}
+
 func generateFunctions(f *os.File, typeName, retType string) {
 	lower := strings.ToLower(typeName)
 
@@ -5,11 +5,11 @@ go 1.24.5
 require (
 	github.com/BurntSushi/toml v1.5.0
 	github.com/adrg/xdg v0.5.3
+	github.com/fsnotify/fsnotify v1.9.0
 	github.com/goccy/go-yaml v1.18.0
 	github.com/google/uuid v1.6.0
 	github.com/hjson/hjson-go/v4 v4.5.0
 	github.com/spf13/cast v1.9.2
 	github.com/spf13/pflag v1.0.7
+	golang.org/x/sys v0.26.0
 )
-
-require golang.org/x/sys v0.26.0 // indirect
@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -87,12 +88,19 @@ func (kp KeyPart) Int() int {
 	return Must(cast.ToIntE(kp.Interface))
 }
 
-// KeySplit parses a dotted key path into parts, respecting quotes.
+// KeySplit parses a dotted key path into parts, respecting quotes. Unquoted
+// parts holding a number without leading zeros, and digits inside brackets,
+// become IndexKey parts addressing slice elements. Other digit parts, such as
+// "007", stay names.
 // Example:
 //
 // "a.b.c"         -> {"a", "b", "c"}
 // "a.'b.c'.\"c\"" -> {"a", "b.c", "c"}
 // "'a.b'.c"       -> {"a.b", "c"}
+// "a.0.b"         -> {"a", 0, "b"}
+// "a[0].b"        -> {"a", 0, "b"}
+// "a.'0'"         -> {"a", "0"}
+// "a.007"         -> {"a", "007"}
 func KeySplit(key string) (Key, error) {
 	out := Key{
 		Raw:   key,
@@ -106,9 +114,43 @@ func KeySplit(key string) (Key, error) {
 	}
 
 	inQuotes := false
+	quoted := false
 	quoteChar := rune(0)
+	// afterIndex is set after "[n]" so that the following "." or "[" does
+	// not produce an empty part.
+	afterIndex := false
+
+	flush := func() error {
+		str := buf.String()
+		if !quoted && isIndex(str) {
+			index, err := parseIndex(str)
+			if err != nil {
+				return err
+			}
+			out.Parts = append(out.Parts, KeyPart{IndexKey, index})
+		} else {
+			out.Parts = append(out.Parts, KeyPart{StringKey, str})
+		}
+		buf.Reset()
+		quoted = false
+		return nil
+	}
+
+	for i := 0; i < len(key); {
+		r, width := utf8.DecodeRuneInString(key[i:])
+
+		if afterIndex && !inQuotes {
+			afterIndex = false
+			switch r {
+			case '.':
+				i += width
+				continue
+			case '[':
+			default:
+				return out, fmt.Errorf("unexpected %q after index at position %d", r, i)
+			}
+		}
 
-	for i, r := range key {
 		switch {
 		case (r == '\'' || r == '"'):
 			if inQuotes {
@@ -123,26 +165,50 @@ func KeySplit(key string) (Key, error) {
 			} else {
 				// Start quote
 				inQuotes = true
+				quoted = true
 				quoteChar = r
 			}
 
 		case r == '.' && !inQuotes:
 			// Dot outside quotes = new part
-			out.Parts = append(out.Parts, KeyPart{StringKey, buf.String()})
-			buf.Reset()
+			if err := flush(); err != nil {
+				return out, err
+			}
+
+		case r == '[' && !inQuotes:
+			end := strings.IndexByte(key[i:], ']')
+			if end < 0 || !isDigits(key[i+1:i+end]) {
+				return out, fmt.Errorf("invalid index at position %d", i)
+			}
+			if buf.Len() > 0 || quoted {
+				if err := flush(); err != nil {
+					return out, err
+				}
+			}
+			index, err := parseIndex(key[i+1 : i+end])
+			if err != nil {
+				return out, err
+			}
+			out.Parts = append(out.Parts, KeyPart{IndexKey, index})
+			afterIndex = true
+			i += end + 1
+			continue
 
 		case r == '\\':
 			// Handle escapes
-			if i+1 >= len(key) {
+			if i+width >= len(key) {
 				return out, fmt.Errorf("dangling escape at position %d", i)
 			}
-			nextRune, width := utf8.DecodeRuneInString(key[i+1:])
+			nextRune, nextWidth := utf8.DecodeRuneInString(key[i+width:])
 			buf.WriteRune(nextRune)
-			i += width - 1 // skip consumed rune
+			quoted = true
+			i += width + nextWidth
+			continue
 
 		default:
 			buf.WriteRune(r)
 		}
+		i += width
 	}
 
 	if inQuotes {
@@ -150,6 +216,63 @@ func KeySplit(key string) (Key, error) {
 	}
 
 	// Last part
-	out.Parts = append(out.Parts, KeyPart{StringKey, buf.String()})
+	if !afterIndex {
+		if err := flush(); err != nil {
+			return out, err
+		}
+	}
 	return out, nil
 }
+
+// parseIndex parses the digits of an index part in base 10, so "08" is 8.
+func parseIndex(digits string) (int, error) {
+	index, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, fmt.Errorf("index %s is out of range", digits)
+	}
+	return index, nil
+}
+
+// isIndex reports whether the unquoted part s is an index: a number without
+// leading zeros, which KeyPart.String formats back to s.
+func isIndex(s string) bool {
+	return isDigits(s) && (len(s) == 1 || s[0] != '0')
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatKey joins parts into a key string that KeySplit parses back into the
+// same parts. Special characters in string parts are escaped, and string parts
+// made only of digits are quoted so they are not read as indexes.
+func formatKey(parts []KeyPart) string {
+	var b strings.Builder
+	for i, part := range parts {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		str := part.String()
+		if part.Kind == StringKey && isDigits(str) {
+			b.WriteString("'" + str + "'")
+			continue
+		}
+		for _, r := range str {
+			switch r {
+			case '.', '\'', '"', '[', ']', '\\':
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
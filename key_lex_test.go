@@ -0,0 +1,127 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+func TestKeySplit(t *testing.T) {
+	str := func(s string) config.KeyPart { return config.KeyPart{Kind: config.StringKey, Interface: s} }
+	idx := func(i int) config.KeyPart { return config.KeyPart{Kind: config.IndexKey, Interface: i} }
+
+	tests := []struct {
+		name    string
+		key     string
+		want    []config.KeyPart
+		wantErr bool
+	}{
+		{"simple", "a.b.c", []config.KeyPart{str("a"), str("b"), str("c")}, false},
+		{"quoted dot", "a.'b.c'", []config.KeyPart{str("a"), str("b.c")}, false},
+		{"escaped dot", `a\.b.c`, []config.KeyPart{str("a.b"), str("c")}, false},
+		{"dotted index", "servers.0.host", []config.KeyPart{str("servers"), idx(0), str("host")}, false},
+		{"bracket index", "servers[1].host", []config.KeyPart{str("servers"), idx(1), str("host")}, false},
+		{"nested brackets", "grid[1][2]", []config.KeyPart{str("grid"), idx(1), idx(2)}, false},
+		{"quoted number", "a.'0'", []config.KeyPart{str("a"), str("0")}, false},
+		{"leading zero", "a.08", []config.KeyPart{str("a"), str("08")}, false},
+		{"zero", "a.0", []config.KeyPart{str("a"), idx(0)}, false},
+		{"bracket leading zero", "a[08]", []config.KeyPart{str("a"), idx(8)}, false},
+		{"index overflow", "a.99999999999999999999", nil, true},
+		{"bracket index overflow", "a[99999999999999999999]", nil, true},
+		{"unclosed bracket", "a[0", nil, true},
+		{"non numeric bracket", "a[x]", nil, true},
+		{"junk after index", "a[0]b", nil, true},
+		{"unclosed quote", "a.'b", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := config.KeySplit(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("KeySplit(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Parts, tt.want) {
+				t.Errorf("KeySplit(%q) = %#v, want %#v", tt.key, got.Parts, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidIndexDoesNotPanic(t *testing.T) {
+	c := config.New()
+	c.Set("a.08", "eight")
+	if got := c.GetString("a.08"); got != "eight" {
+		t.Errorf(`GetString("a.08") = %q, want "eight"`, got)
+	}
+	if _, err := c.GetE("a.8"); err == nil {
+		t.Error(`GetE("a.8") should not find the value of "a.08"`)
+	}
+	if _, err := c.GetE("a.99999999999999999999"); err == nil {
+		t.Error(`GetE("a.99999999999999999999") should fail`)
+	}
+	if err := c.Set("a[99999999999999999999]", 1); err == nil {
+		t.Error(`Set("a[99999999999999999999]") should fail`)
+	}
+
+	var v struct {
+		Value string `config:"a.08"`
+		Huge  string `config:"a.99999999999999999999"`
+	}
+	var be config.BindError
+	if err := c.Bind("", &v); !errors.As(err, &be) || be.Key != "a.99999999999999999999" {
+		t.Errorf("Bind() error = %v, want an error for the huge index", err)
+	}
+	if v.Value != "eight" {
+		t.Errorf("Value = %q, want eight", v.Value)
+	}
+}
+
+func TestLeadingZeroKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "codes.yaml")
+	if err := os.WriteFile(path, []byte("codes:\n  \"007\":\n    name: bond\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := config.New()
+	c.AddFile(path)
+	if err := c.ReadConfig(); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+
+	if got, err := c.GetStringE("codes.007.name"); err != nil || got != "bond" {
+		t.Errorf(`GetStringE("codes.007.name") = %q, %v, want "bond"`, got, err)
+	}
+
+	got, err := c.Query("codes.007.*")
+	if want := map[string]any{"codes.'007'.name": "bond"}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf(`Query("codes.007.*") = %v, %v, want %v`, got, err, want)
+	}
+
+	var v struct {
+		Codes map[string]struct {
+			Name string `config:"name" check:"required"`
+		} `config:"codes"`
+	}
+	if err := c.Bind("", &v); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if v.Codes["007"].Name != "bond" {
+		t.Errorf(`Codes["007"].Name = %q, want "bond"`, v.Codes["007"].Name)
+	}
+}
+
+func TestEnvKeyLeadingDigit(t *testing.T) {
+	key, err := config.KeySplit("auth.2fa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := key.EnvKey("app"); got != "APP_AUTH___2fa" {
+		t.Errorf("EnvKey() = %q, want %q", got, "APP_AUTH___2fa")
+	}
+}
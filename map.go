@@ -81,3 +81,24 @@ func toStringAnyMap(rv reflect.Value) map[string]any {
 	}
 	return out
 }
+
+// deepCopy returns a copy of v where every nested map[string]any and []any is
+// copied as well. Other values are returned as is.
+func deepCopy(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, vv := range v {
+			out[k] = deepCopy(vv)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, vv := range v {
+			out[i] = deepCopy(vv)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ValidationError describes a value that failed a "check" rule. Validate
+// returns it for every failure of the value itself; problems with the tag are
+// TagErrors. Its message comes from the function set with SetMessageFunc or
+// the table set with SetMessages, falling back to Err.
+type ValidationError struct {
+	// Key is the config key of the value, such as "servers.0.port". It is
+	// the field name, or the field name and the element for rules after
+	// dive, when Validate is called directly.
+	Key string
+	// Field is the name of the struct field.
+	Field string
+	// Rule is the name of the rule that failed, such as "min".
+	Rule string
+	// Param is the parameter of the rule, such as "8" for min=8, or empty.
+	Param string
+	// Value is the value that failed the rule, after transforms.
+	Value any
+	// Err is the cause, which holds the default message.
+	Err error
+}
+
+func (ve ValidationError) Error() string {
+	if msg, ok := ve.message(); ok {
+		return msg
+	}
+	return ve.Err.Error()
+}
+
+// message returns the message from the SetMessageFunc function or the
+// SetMessages table. ok is false if neither has one for ve.
+func (ve ValidationError) message() (msg string, ok bool) {
+	messages.RLock()
+	fn, format := messages.fn, messages.table[ve.Rule]
+	messages.RUnlock()
+
+	if fn != nil {
+		if msg := fn(ve); msg != "" {
+			return msg, true
+		}
+	}
+	if format != "" {
+		return ve.format(format), true
+	}
+	return "", false
+}
+
+func (ve ValidationError) Unwrap() error {
+	return ve.Err
+}
+
+// format replaces the placeholders of a message of the SetMessages table.
+func (ve ValidationError) format(format string) string {
+	return strings.NewReplacer(
+		"{key}", ve.Key,
+		"{field}", ve.Field,
+		"{rule}", ve.Rule,
+		"{param}", ve.Param,
+		"{value}", fmt.Sprint(ve.Value),
+		"{error}", ve.Err.Error(),
+	).Replace(format)
+}
+
+// messages holds the message function and table of ValidationError.
+var messages = struct {
+	sync.RWMutex
+	fn    func(ValidationError) string
+	table map[string]string
+}{}
+
+// SetMessageFunc sets the function that renders the messages of
+// ValidationErrors, so applications can word or translate them. If fn returns
+// an empty string, the message from the SetMessages table or the default
+// message is used. fn must not call ve.Error; ve.Err holds the default
+// message. A nil fn restores the default.
+//
+// Example:
+//
+//	config.SetMessageFunc(func(ve config.ValidationError) string {
+//		if ve.Rule == "required" {
+//			return ve.Key + " is missing"
+//		}
+//		return ""
+//	})
+func SetMessageFunc(fn func(ve ValidationError) string) {
+	messages.Lock()
+	defer messages.Unlock()
+	messages.fn = fn
+}
+
+// SetMessages sets the table of messages of ValidationErrors by rule name. The
+// messages may contain the placeholders {key}, {field}, {rule}, {param},
+// {value} and {error}, the default message. Rules not in the table keep their
+// default message. A nil table restores the defaults.
+//
+// BindError reports custom messages, from the table or from SetMessageFunc,
+// as they are, without prefixing the key and the rule.
+//
+// Example:
+//
+//	config.SetMessages(map[string]string{
+//		"required": "{key} ist erforderlich",
+//		"min":      "{key} muss mindestens {param} sein",
+//	})
+func SetMessages(table map[string]string) {
+	messages.Lock()
+	defer messages.Unlock()
+	messages.table = table
+}
@@ -0,0 +1,98 @@
+package config_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+func TestValidationError(t *testing.T) {
+	type App struct {
+		Name string `config:"name" check:"trim,min=3"`
+		Port int    `config:"port" check:"min=1,max=65535"`
+	}
+
+	sf, _ := reflect.TypeFor[App]().FieldByName("Name")
+	value := reflect.ValueOf(&App{Name: " ab "}).Elem().Field(0)
+	err := config.Validate(sf, value, true)
+
+	var ve config.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("Validate() error = %v, want ValidationError", err)
+	}
+	want := config.ValidationError{Key: "Name", Field: "Name", Rule: "min", Param: "3", Value: "ab", Err: ve.Err}
+	if !reflect.DeepEqual(ve, want) {
+		t.Errorf("Validate() error = %#v, want %#v", ve, want)
+	}
+
+	c := config.New()
+	c.Set("app.name", "MyApp")
+	c.Set("app.port", 70000)
+	err = c.Bind("app", &App{})
+	if !errors.As(err, &ve) {
+		t.Fatalf("Bind() error = %v, want ValidationError", err)
+	}
+	if ve.Key != "app.port" || ve.Rule != "max" || ve.Param != "65535" || ve.Value != 70000 {
+		t.Errorf("Bind() error = %#v", ve)
+	}
+	if got := err.Error(); got != "app.port: max: 70000 is greater than the maximum (65535)" {
+		t.Errorf("Bind() error = %q", got)
+	}
+}
+
+func TestSetMessages(t *testing.T) {
+	defer config.SetMessages(nil)
+	defer config.SetMessageFunc(nil)
+
+	type App struct {
+		Name  string `config:"name" check:"required"`
+		Port  int    `config:"port" check:"min=1"`
+		Email string `config:"email" check:"email"`
+	}
+
+	config.SetMessages(map[string]string{
+		"required": "{key} ist erforderlich",
+		"min":      "{field} muss mindestens {param} sein, nicht {value}",
+	})
+
+	c := config.New()
+	c.Set("app.port", 0)
+	c.Set("app.email", "not-an-email")
+
+	bind := func() map[string]string {
+		var errs config.BindErrors
+		if !errors.As(c.Bind("app", &App{}), &errs) {
+			t.Fatal("Bind() should fail with BindErrors")
+		}
+		got := map[string]string{}
+		for _, e := range errs {
+			got[e.Rule] = e.Error()
+		}
+		return got
+	}
+
+	got := bind()
+	want := map[string]string{
+		"required": "app.name ist erforderlich",
+		"min":      "Port muss mindestens 1 sein, nicht 0",
+		"email":    `app.email: email: invalid email: mail: missing '@' or angle-addr`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("messages:\nGot: %v\nWant: %v", got, want)
+	}
+
+	config.SetMessageFunc(func(ve config.ValidationError) string {
+		if ve.Rule == "email" {
+			return fmt.Sprintf("%s: %q is no email", ve.Key, ve.Value)
+		}
+		return ""
+	})
+	got = bind()
+	want["email"] = `app.email: "not-an-email" is no email`
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("messages with func:\nGot: %v\nWant: %v", got, want)
+	}
+}
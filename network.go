@@ -0,0 +1,163 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	netIPType       = reflect.TypeOf(net.IP{})
+	hardwareType    = reflect.TypeOf(net.HardwareAddr{})
+	netipAddrType   = reflect.TypeOf(netip.Addr{})
+	netipPrefixType = reflect.TypeOf(netip.Prefix{})
+	urlType         = reflect.TypeOf(url.URL{})
+)
+
+// validateNetwork applies the url, hostname, ip, ipv4, ipv6, cidr, port,
+// hostport and mac rules. They accept strings and the types that hold the
+// parsed value: net.IP and netip.Addr for ip, ipv4 and ipv6, netip.Prefix for
+// cidr, url.URL for url, net.HardwareAddr for mac and integers for port.
+func validateNetwork(sfv reflect.Value, name string, rule any) error {
+	t := sfv.Type()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	value := resolvePointer(sfv)
+
+	zero := reflect.Zero(t)
+	switch {
+	case t.Kind() == reflect.String:
+	case name == "url" && t == urlType:
+	case (name == "ip" || name == "ipv4" || name == "ipv6") && (t == netIPType || t == netipAddrType):
+	case name == "cidr" && t == netipPrefixType:
+	case name == "mac" && t == hardwareType:
+	case name == "port" && (zero.CanInt() || zero.CanUint()):
+	default:
+		return tagErrorf("%s does not support %s validation", t, name)
+	}
+
+	// Values are checked in their text form; nil pointers and zero values,
+	// like a nil net.IP, are empty.
+	var str string
+	switch {
+	case !value.IsValid() || value.IsZero():
+	case t == urlType:
+		u := value.Interface().(url.URL)
+		str = u.String()
+	default:
+		str = fmt.Sprint(value.Interface())
+	}
+
+	switch name {
+	case "url":
+		return validateURL(str, rule)
+	case "hostname":
+		if !isHostname(str) {
+			return fmt.Errorf("%q is not a valid hostname", str)
+		}
+	case "ip", "ipv4", "ipv6":
+		return validateIP(str, name)
+	case "cidr":
+		if _, err := netip.ParsePrefix(str); err != nil {
+			return fmt.Errorf("%q is not a valid CIDR", str)
+		}
+	case "port":
+		if !isPort(str) {
+			return fmt.Errorf("%q is not a valid port", str)
+		}
+	case "hostport":
+		host, port, err := net.SplitHostPort(str)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid host and port: %w", str, err)
+		}
+		if _, err := netip.ParseAddr(host); host != "" && err != nil && !isHostname(host) {
+			return fmt.Errorf("%q has an invalid host %q", str, host)
+		}
+		if !isPort(port) {
+			return fmt.Errorf("%q has an invalid port %q", str, port)
+		}
+	case "mac":
+		if _, err := net.ParseMAC(str); err != nil {
+			return fmt.Errorf("%q is not a valid MAC address", str)
+		}
+	}
+	return nil
+}
+
+// validateURL applies the url rule. The parameter is an optional comma (,)
+// separated list of allowed schemes: url='http,https'.
+func validateURL(str string, rule any) error {
+	var schemes []string
+	if param, ok := rule.(string); ok {
+		for scheme := range strings.SplitSeq(param, ",") {
+			if scheme = strings.TrimSpace(scheme); scheme != "" {
+				schemes = append(schemes, strings.ToLower(scheme))
+			}
+		}
+		if len(schemes) == 0 {
+			return tagErrorf("url needs at least one scheme")
+		}
+	}
+
+	u, err := url.Parse(str)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" && u.Opaque == "" && u.Path == "" {
+		return fmt.Errorf("%q is not an absolute URL", str)
+	}
+	if len(schemes) != 0 && !slices.Contains(schemes, strings.ToLower(u.Scheme)) {
+		return fmt.Errorf("URL scheme %q must be one of %v", u.Scheme, schemes)
+	}
+	return nil
+}
+
+// validateIP applies the ip, ipv4 and ipv6 rules. IPv4-mapped IPv6 addresses,
+// like net.IP holds IPv4 addresses, are IPv4 addresses.
+func validateIP(str string, name string) error {
+	addr, err := netip.ParseAddr(str)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid IP address", str)
+	}
+	addr = addr.Unmap()
+	switch {
+	case name == "ipv4" && !addr.Is4():
+		return fmt.Errorf("%q is not an IPv4 address", str)
+	case name == "ipv6" && !addr.Is6():
+		return fmt.Errorf("%q is not an IPv6 address", str)
+	}
+	return nil
+}
+
+// isHostname reports whether s is a valid RFC 1123 hostname, optionally fully
+// qualified with a trailing dot.
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for label := range strings.SplitSeq(s, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isPort reports whether s is a port number between 1 and 65535.
+func isPort(s string) bool {
+	port, err := strconv.ParseUint(s, 10, 16)
+	return err == nil && port != 0
+}
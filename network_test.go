@@ -0,0 +1,157 @@
+package config_test
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+func TestNetworkRules(t *testing.T) {
+	type Network struct {
+		URL      string           `check:"url"`
+		HTTPURL  string           `check:"url='http,https'"`
+		Endpoint *url.URL         `check:"url=https"`
+		Host     string           `check:"hostname"`
+		IP       string           `check:"ip"`
+		IPv4     net.IP           `check:"ipv4"`
+		IPv6     netip.Addr       `check:"ipv6"`
+		CIDR     netip.Prefix     `check:"cidr"`
+		Subnet   string           `check:"cidr"`
+		Port     int              `check:"port"`
+		PortStr  string           `check:"port"`
+		Listen   string           `check:"hostport"`
+		MAC      string           `check:"mac"`
+		HWAddr   net.HardwareAddr `check:"mac"`
+	}
+
+	tests := []struct {
+		field   string
+		value   any
+		wantErr bool
+	}{
+		{"URL", "postgres://db.internal:5432/app", false},
+		{"URL", "mailto:ops@example.com", false},
+		{"URL", "/relative/path", true},
+		{"URL", "", true},
+		{"HTTPURL", "https://example.com", false},
+		{"HTTPURL", "ftp://example.com", true},
+		{"Endpoint", &url.URL{Scheme: "https", Host: "api.example.com"}, false},
+		{"Endpoint", &url.URL{Scheme: "http", Host: "api.example.com"}, true},
+		{"Endpoint", (*url.URL)(nil), true},
+		{"Host", "api.example.com", false},
+		{"Host", "localhost.", false},
+		{"Host", "-bad.example.com", true},
+		{"Host", "under_score.com", true},
+		{"IP", "10.0.0.1", false},
+		{"IP", "::1", false},
+		{"IP", "10.0.0.256", true},
+		{"IPv4", net.ParseIP("192.168.1.1"), false},
+		{"IPv4", net.ParseIP("2001:db8::1"), true},
+		{"IPv4", net.IP(nil), true},
+		{"IPv6", netip.MustParseAddr("2001:db8::1"), false},
+		{"IPv6", netip.MustParseAddr("192.168.1.1"), true},
+		{"IPv6", netip.Addr{}, true},
+		{"CIDR", netip.MustParsePrefix("10.0.0.0/8"), false},
+		{"CIDR", netip.Prefix{}, true},
+		{"Subnet", "2001:db8::/32", false},
+		{"Subnet", "10.0.0.0/33", true},
+		{"Port", 8080, false},
+		{"Port", 0, true},
+		{"Port", 70000, true},
+		{"PortStr", "443", false},
+		{"PortStr", "https", true},
+		{"Listen", ":8080", false},
+		{"Listen", "example.com:443", false},
+		{"Listen", "[::1]:53", false},
+		{"Listen", "example.com", true},
+		{"Listen", "example.com:0", true},
+		{"Listen", "bad_host:80", true},
+		{"MAC", "00:1a:2b:3c:4d:5e", false},
+		{"MAC", "00:1a:2b:3c:4d", true},
+		{"HWAddr", net.HardwareAddr{0, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			sf, _ := reflect.TypeFor[Network]().FieldByName(tt.field)
+			value := reflect.New(sf.Type).Elem()
+			value.Set(reflect.ValueOf(tt.value))
+
+			err := config.Validate(sf, value, true)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%v) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			var te config.TagError
+			if errors.As(err, &te) {
+				t.Errorf("Validate(%v) error = %v, want a value error", tt.value, err)
+			}
+		})
+	}
+
+	type Invalid struct {
+		Count  int          `check:"ip"`
+		URL    netip.Addr   `check:"url"`
+		Prefix netip.Prefix `check:"ip"`
+		Port   float64      `check:"port"`
+		Scheme string       `check:"url=''"`
+	}
+	err := config.ValidateTags(reflect.TypeFor[Invalid]())
+	for field := range strings.SplitSeq("Count URL Prefix Port Scheme", " ") {
+		if err == nil || !strings.Contains(err.Error(), "on "+field+":") {
+			t.Errorf("ValidateTags() error = %v, want %s", err, field)
+		}
+	}
+}
+
+func TestBindNetworkTypes(t *testing.T) {
+	type Server struct {
+		Bind     netip.Addr     `config:"bind" check:"ip"`
+		Allowed  []netip.Prefix `config:"allowed" check:"dive,cidr"`
+		Gateway  net.IP         `config:"gateway" check:"ipv4"`
+		Upstream *url.URL       `config:"upstream" check:"url='http,https'"`
+		Listen   string         `config:"listen" check:"hostport"`
+	}
+
+	c := config.New()
+	c.Set("server.bind", "10.0.0.1")
+	c.Set("server.allowed", []any{"10.0.0.0/8", "192.168.0.0/16"})
+	c.Set("server.gateway", "10.0.0.254")
+	c.Set("server.upstream", "https://backend.internal:8443/api")
+	c.Set("server.listen", ":8080")
+
+	var s Server
+	if err := c.Bind("server", &s); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if s.Bind != netip.MustParseAddr("10.0.0.1") || !s.Gateway.Equal(net.ParseIP("10.0.0.254")) {
+		t.Errorf("Bind = %v, Gateway = %v", s.Bind, s.Gateway)
+	}
+	if len(s.Allowed) != 2 || s.Allowed[1] != netip.MustParsePrefix("192.168.0.0/16") {
+		t.Errorf("Allowed = %v", s.Allowed)
+	}
+	if s.Upstream == nil || s.Upstream.Host != "backend.internal:8443" {
+		t.Errorf("Upstream = %v", s.Upstream)
+	}
+
+	c.Set("server.upstream", "ftp://backend.internal")
+	c.Set("server.gateway", "not-an-ip")
+	err := c.Bind("server", &s)
+	var errs config.BindErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Bind() error = %v, want BindErrors", err)
+	}
+	got := map[string]string{}
+	for _, e := range errs {
+		got[e.Key] = e.Rule
+	}
+	want := map[string]string{"server.gateway": "", "server.upstream": "url"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Bind() errors = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// SourceProvider is implemented by providers that can tell where a value is
+// stored. It is used by Origin and Explain.
+type SourceProvider interface {
+	// Source returns where the value for the key is stored, outermost first.
+	// For config files this is the include chain ending with the file that
+	// set the value.
+	Source(key Key) []string
+}
+
+// Provenance describes which provider supplied a value.
+type Provenance struct {
+	// Provider is the name of the provider.
+	Provider string
+	// Layer is the built-in layer of the provider, or zero for custom
+	// providers.
+	Layer Layer
+	// Source is where the provider stored the value: the flag, the
+	// environment variable or the config file. It is empty if the provider
+	// can't tell.
+	Source string
+	// IncludedFrom lists the config files that included Source, outermost
+	// first.
+	IncludedFrom []string
+	// Value is the value supplied by the provider.
+	Value any
+}
+
+// String returns a short description such as
+// "env APP_PORT" or "file /etc/app/db.yaml (included from /etc/app/config.json)".
+func (p Provenance) String() string {
+	var b strings.Builder
+	b.WriteString(p.Provider)
+	if p.Source != "" {
+		b.WriteString(" " + p.Source)
+	}
+	if len(p.IncludedFrom) != 0 {
+		b.WriteString(" (included from " + strings.Join(p.IncludedFrom, " → ") + ")")
+	}
+	return b.String()
+}
+
+// Explanation lists every provider that has a value for a key.
+type Explanation struct {
+	// Key is the explained key.
+	Key string
+	// Origin is the provider whose value is used.
+	Origin Provenance
+	// Shadowed are the providers whose values are overridden by Origin,
+	// highest precedence first.
+	Shadowed []Provenance
+}
+
+// String returns a multi-line report of the explanation.
+func (e Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s = %v (from %s)", e.Key, e.Origin.Value, e.Origin)
+	for p := range slices.Values(e.Shadowed) {
+		fmt.Fprintf(&b, "\n  shadows %v (from %s)", p.Value, p)
+	}
+	return b.String()
+}
+
+// Origin returns which provider supplied the value GetE returns for the key.
+func (c *Config) Origin(key string) (Provenance, error) {
+	e, err := c.Explain(key)
+	if err != nil {
+		return Provenance{}, err
+	}
+	return e.Origin, nil
+}
+
+// Explain returns which provider supplied the value for the key and which
+// values of lower precedence it shadows.
+//
+// Example:
+//
+//	app.port = 9000 (from env APP_APP__PORT)
+//	  shadows 8080 (from file /etc/app/app.yaml (included from /etc/app/config.yaml))
+//	  shadows 80 (from defaults)
+func (c *Config) Explain(key string) (Explanation, error) {
+	parsed, err := KeySplit(key)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	found := []Provenance{}
+	for e := range slices.Values(c.load().providers) {
+		v, ok := e.provider.Lookup(parsed)
+		if !ok {
+			continue
+		}
+		p := Provenance{Provider: e.provider.Name(), Layer: e.layer, Value: v}
+		if sp, ok := e.provider.(SourceProvider); ok {
+			if source := sp.Source(parsed); len(source) != 0 {
+				p.Source = source[len(source)-1]
+				p.IncludedFrom = source[:len(source)-1]
+			}
+		}
+		found = append(found, p)
+	}
+
+	if len(found) == 0 {
+		return Explanation{}, KeyError{key}
+	}
+	return Explanation{Key: key, Origin: found[0], Shadowed: found[1:]}, nil
+}
+
+// setOrigin returns a copy of origins where key and the keys below it are
+// marked as set with Set.
+func setOrigin(origins map[string][]string, key string) map[string][]string {
+	out := map[string][]string{}
+	parsed, err := KeySplit(key)
+	if err != nil || key == "." {
+		return out
+	}
+
+	name := formatKey(parsed.Parts)
+	for k, chain := range maps.All(origins) {
+		if k != name && !strings.HasPrefix(k, name+".") {
+			out[k] = chain
+		}
+	}
+	out[name] = nil
+	return out
+}
+
+// lookupOrigin returns the include chain of the file that set the key, or of
+// its closest parent. ok is false if the key isn't known.
+func lookupOrigin(origins map[string][]string, key Key) (chain []string, ok bool) {
+	for i := key.Len(); i > 0; i-- {
+		if chain, ok := origins[formatKey(key.Parts[:i])]; ok {
+			return chain, true
+		}
+	}
+	return nil, false
+}
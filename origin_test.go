@@ -0,0 +1,123 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+func TestOrigin(t *testing.T) {
+	abs := func(name string) string {
+		return config.Must(filepath.Abs(filepath.Join("test", name)))
+	}
+
+	c := config.New()
+	c.AddFile(abs("config.json"))
+	c.SetFormat("json")
+	if err := c.ReadConfig(); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	c.Set("app.mode", "fast")
+
+	tests := []struct {
+		key  string
+		want config.Provenance
+	}{
+		{
+			key: "app.port",
+			want: config.Provenance{
+				Provider:     "file",
+				Layer:        config.LayerFile,
+				Source:       abs("config.json"),
+				IncludedFrom: []string{},
+				Value:        "8080",
+			},
+		},
+		{
+			key: "app.env",
+			want: config.Provenance{
+				Provider:     "file",
+				Layer:        config.LayerFile,
+				Source:       abs("included.yaml"),
+				IncludedFrom: []string{abs("config.json")},
+				Value:        "production",
+			},
+		},
+		{
+			key: "app.debug",
+			want: config.Provenance{
+				Provider:     "file",
+				Layer:        config.LayerFile,
+				Source:       abs("includedbyyaml.toml"),
+				IncludedFrom: []string{abs("config.json"), abs("included.yaml")},
+				Value:        "true",
+			},
+		},
+		{
+			key: "logging.level",
+			want: config.Provenance{
+				Provider:     "file",
+				Layer:        config.LayerFile,
+				Source:       abs("include2.jsonc"),
+				IncludedFrom: []string{abs("config.json")},
+				Value:        "debug",
+			},
+		},
+		{
+			key: "app.mode",
+			want: config.Provenance{
+				Provider:     "file",
+				Layer:        config.LayerFile,
+				Source:       "(set in code)",
+				IncludedFrom: []string{},
+				Value:        "fast",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := c.Origin(tt.key)
+			if err != nil {
+				t.Fatalf("Origin(%q) error = %v", tt.key, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Origin(%q):\nGot: %#v\nWant: %#v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	c := config.New()
+	c.SetEnvPrefix("EXPLAIN")
+	c.Set("app.port", 8080)
+	c.SetDefault("app.port", 80)
+	_ = os.Setenv("EXPLAIN_APP__PORT", "9000")
+	defer os.Unsetenv("EXPLAIN_APP__PORT")
+
+	e, err := c.Explain("app.port")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+
+	if got := e.Origin.String(); got != "env EXPLAIN_APP__PORT" {
+		t.Errorf("Origin = %q, want %q", got, "env EXPLAIN_APP__PORT")
+	}
+	if len(e.Shadowed) != 2 {
+		t.Fatalf("Shadowed = %v, want 2 entries", e.Shadowed)
+	}
+	if e.Shadowed[0].Layer != config.LayerFile || e.Shadowed[0].Value != 8080 {
+		t.Errorf("Shadowed[0] = %#v, want file value 8080", e.Shadowed[0])
+	}
+	if e.Shadowed[1].Layer != config.LayerDefaults || e.Shadowed[1].Value != 80 {
+		t.Errorf("Shadowed[1] = %#v, want default value 80", e.Shadowed[1])
+	}
+
+	if _, err := c.Explain("app.missing"); err == nil {
+		t.Error("Explain() of a missing key should fail")
+	}
+}
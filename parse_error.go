@@ -0,0 +1,145 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/goccy/go-yaml"
+)
+
+// ParseError indicates a config file could not be decoded. The position is
+// taken from the errors of the json, hjson, yaml and toml decoders; for other
+// decoders Line and Column are zero.
+//
+// Example:
+//
+//	var pe config.ParseError
+//	if errors.As(err, &pe) {
+//		fmt.Fprintf(os.Stderr, "%v\n%s\n", pe, pe.Excerpt)
+//	}
+//
+// Output:
+//
+//	/etc/app/config.yaml:3:6: yaml: sequence end token ']' not found
+//	  c: [1, 2
+//	     ^
+type ParseError struct {
+	// Path is the file that failed to decode.
+	Path string
+	// IncludedFrom lists the files that included Path, outermost first.
+	IncludedFrom []string
+	// Format is the extension of the file, such as "yaml".
+	Format string
+	// Line and Column are the 1-based position of the error, or zero if
+	// unknown.
+	Line   int
+	Column int
+	// Excerpt is the offending line followed by a line with a caret under
+	// the column. It is empty if the position is unknown.
+	Excerpt string
+	// Message is the error message of the decoder without the position.
+	Message string
+	// Err is the error returned by the decoder.
+	Err error
+}
+
+func (pe ParseError) Error() string {
+	var b strings.Builder
+	b.WriteString(pe.Path)
+	if pe.Line > 0 {
+		fmt.Fprintf(&b, ":%d:%d", pe.Line, pe.Column)
+	}
+	if len(pe.IncludedFrom) != 0 {
+		b.WriteString(" (included from " + strings.Join(pe.IncludedFrom, " → ") + ")")
+	}
+	fmt.Fprintf(&b, ": %s: %s", pe.Format, pe.Message)
+	return b.String()
+}
+
+func (pe ParseError) Unwrap() error {
+	return pe.Err
+}
+
+// hjsonPosition matches the position hjson appends to its errors.
+var hjsonPosition = regexp.MustCompile(`^(.*) at line (\d+),(\d+) >>> `)
+
+// newParseError returns a ParseError for the error of a decoder, with the
+// position and the excerpt taken from the error and the source b.
+func newParseError(format string, b []byte, err error) ParseError {
+	pe := ParseError{Format: format, Message: err.Error(), Err: err}
+
+	var (
+		yamlErr       yaml.Error
+		tomlErr       toml.ParseError
+		jsonSyntaxErr *json.SyntaxError
+		jsonTypeErr   *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &yamlErr):
+		pe.Message = yamlErr.GetMessage()
+		if tk := yamlErr.GetToken(); tk != nil && tk.Position != nil {
+			pe.Line, pe.Column = tk.Position.Line, tk.Position.Column
+		}
+	case errors.As(err, &tomlErr):
+		pe.Message = tomlErr.Message
+		pe.Line, pe.Column = tomlErr.Position.Line, tomlErr.Position.Col
+	case errors.As(err, &jsonSyntaxErr):
+		// The offset points past the offending byte.
+		pe.Line, pe.Column = offsetPosition(b, int(jsonSyntaxErr.Offset)-1)
+	case errors.As(err, &jsonTypeErr):
+		pe.Line, pe.Column = offsetPosition(b, int(jsonTypeErr.Offset)-1)
+	default:
+		if m := hjsonPosition.FindStringSubmatch(err.Error()); m != nil {
+			pe.Message = m[1]
+			pe.Line, _ = strconv.Atoi(m[2])
+			pe.Column, _ = strconv.Atoi(m[3])
+		}
+	}
+
+	pe.Excerpt = excerpt(b, pe.Line, pe.Column)
+	return pe
+}
+
+// offsetPosition returns the 1-based line and column of the byte offset in b.
+func offsetPosition(b []byte, offset int) (line, column int) {
+	if offset < 0 || offset > len(b) {
+		return 0, 0
+	}
+	before := b[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = offset - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// excerpt returns the line of b at the 1-based position followed by a caret
+// under the column. Tabs before the column are kept so the caret lines up.
+func excerpt(b []byte, line, column int) string {
+	if line < 1 {
+		return ""
+	}
+	lines := strings.Split(string(b), "\n")
+	if line > len(lines) {
+		return ""
+	}
+	text := strings.TrimRight(lines[line-1], "\r")
+
+	var caret strings.Builder
+	for i, r := range text {
+		if i >= column-1 {
+			break
+		}
+		if r == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+	caret.WriteRune('^')
+	return text + "\n" + caret.String()
+}
@@ -0,0 +1,113 @@
+package config_test
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		line    int
+		column  int
+		excerpt string
+	}{
+		{
+			name:    "json",
+			file:    "config.json",
+			content: "{\n  \"a\": 1,\n  \"b\": }",
+			line:    3,
+			column:  8,
+			excerpt: "  \"b\": }\n       ^",
+		},
+		{
+			name:    "jsonc",
+			file:    "config.jsonc",
+			content: "{\n  // comment\n  \"b\": }",
+			line:    3,
+			column:  8,
+			excerpt: "  \"b\": }\n       ^",
+		},
+		{
+			name:    "yaml",
+			file:    "config.yaml",
+			content: "a: 1\nb:\n  c: [1, 2\n",
+			line:    3,
+			column:  6,
+			excerpt: "  c: [1, 2\n     ^",
+		},
+		{
+			name:    "toml",
+			file:    "config.toml",
+			content: "a = 1\nb = \n",
+			line:    2,
+			column:  5,
+			excerpt: "b = \n    ^",
+		},
+		{
+			name:    "tabs",
+			file:    "config.json",
+			content: "{\n\t\"b\": }",
+			line:    2,
+			column:  7,
+			excerpt: "\t\"b\": }\n\t     ^",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, tt.file)
+			writeFile(t, path, tt.content)
+
+			c := config.New()
+			c.AddFile(path)
+			c.SetStrict(true)
+
+			var pe config.ParseError
+			if err := c.ReadConfig(); !errors.As(err, &pe) {
+				t.Fatalf("ReadConfig() error = %v, want ParseError", err)
+			}
+			if pe.Path != path {
+				t.Errorf("Path = %q, want %q", pe.Path, path)
+			}
+			if pe.Line != tt.line || pe.Column != tt.column {
+				t.Errorf("position = %d:%d, want %d:%d", pe.Line, pe.Column, tt.line, tt.column)
+			}
+			if pe.Excerpt != tt.excerpt {
+				t.Errorf("Excerpt:\nGot:\n%s\nWant:\n%s", pe.Excerpt, tt.excerpt)
+			}
+		})
+	}
+}
+
+func TestParseErrorIncluded(t *testing.T) {
+	dir := t.TempDir()
+	main := filepath.Join(dir, "config.yaml")
+	base := filepath.Join(dir, "base.yaml")
+	broken := filepath.Join(dir, "broken.toml")
+	writeFile(t, main, "include: base.yaml\n")
+	writeFile(t, base, "include: broken.toml\nport: 8080\n")
+	writeFile(t, broken, "port = 1\nhost = \n")
+
+	c := config.New()
+	c.AddFile(main)
+	c.SetStrict(true)
+
+	var pe config.ParseError
+	if err := c.ReadConfig(); !errors.As(err, &pe) {
+		t.Fatalf("ReadConfig() error = %v, want ParseError", err)
+	}
+	if want := []string{main, base}; !slices.Equal(pe.IncludedFrom, want) {
+		t.Errorf("IncludedFrom = %v, want %v", pe.IncludedFrom, want)
+	}
+	if pe.Path != broken || pe.Line != 2 || pe.Format != "toml" {
+		t.Errorf("ParseError = %+v, want %s at line 2", pe, broken)
+	}
+}
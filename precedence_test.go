@@ -0,0 +1,136 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+	"github.com/spf13/pflag"
+)
+
+func TestSetPrecedence(t *testing.T) {
+	_ = os.Setenv("PRECEDENCE_PORT", "1000")
+	defer os.Unsetenv("PRECEDENCE_PORT")
+
+	newConfig := func() *config.Config {
+		c := config.New()
+		c.SetEnvPrefix("PRECEDENCE")
+		c.Set("port", 2000)
+		c.SetDefault("port", 3000)
+		c.SetDefault("host", "localhost")
+
+		fs := pflag.NewFlagSet("app", pflag.ContinueOnError)
+		fs.Int("port", 4000, "")
+		fs.String("host", "flag-host", "")
+		_ = fs.Parse([]string{})
+		c.SetPflagSet(fs)
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		layers []config.Layer
+		key    string
+		want   string
+	}{
+		{
+			name: "default precedence",
+			key:  "port",
+			want: "1000",
+		},
+		{
+			name:   "file beats env",
+			layers: []config.Layer{config.LayerFlags, config.LayerFile, config.LayerEnv, config.LayerDefaults},
+			key:    "port",
+			want:   "2000",
+		},
+		{
+			name:   "flag defaults beat files",
+			layers: []config.Layer{config.LayerFlags, config.LayerFlagDefaults, config.LayerFile, config.LayerDefaults},
+			key:    "port",
+			want:   "4000",
+		},
+		{
+			name:   "defaults beat files",
+			layers: []config.Layer{config.LayerDefaults, config.LayerFile},
+			key:    "port",
+			want:   "3000",
+		},
+		{
+			name:   "omitted layers are not consulted",
+			layers: []config.Layer{config.LayerFlags, config.LayerFile},
+			key:    "host",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newConfig()
+			if tt.layers != nil {
+				if err := c.SetPrecedence(tt.layers...); err != nil {
+					t.Fatalf("SetPrecedence() error = %v", err)
+				}
+			}
+			if v := c.GetString(tt.key); v != tt.want {
+				t.Errorf("GetString(%q) = %q, want %q", tt.key, v, tt.want)
+			}
+			if changed := c.Changed(tt.key); changed != (tt.want != "") {
+				t.Errorf("Changed(%q) = %v, want %v", tt.key, changed, tt.want != "")
+			}
+		})
+	}
+}
+
+func TestSetPrecedenceInvalid(t *testing.T) {
+	c := config.New()
+	if err := c.SetPrecedence(); err == nil {
+		t.Error("SetPrecedence() with no layers should fail")
+	}
+	if err := c.SetPrecedence(config.LayerFile, config.LayerFile); err == nil {
+		t.Error("SetPrecedence() with duplicate layers should fail")
+	}
+	if err := c.SetPrecedence(config.Layer(42)); err == nil {
+		t.Error("SetPrecedence() with unknown layer should fail")
+	}
+}
+
+func TestSetPrecedenceKeepsCustomProviders(t *testing.T) {
+	c := config.New()
+	c.Set("token", "from-file")
+	secrets := &mapProvider{name: "secrets", next: map[string]any{"token": "s3cret"}}
+	c.AddProvider(secrets, config.PriorityEnv+1)
+	_ = secrets.Load()
+
+	if err := c.SetPrecedence(config.LayerFile, config.LayerEnv, config.LayerDefaults); err != nil {
+		t.Fatalf("SetPrecedence() error = %v", err)
+	}
+	// The file layer now holds PriorityFlags, above the custom provider.
+	if v := c.GetString("token"); v != "from-file" {
+		t.Errorf("GetString(\"token\") = %q, want %q", v, "from-file")
+	}
+}
+
+func TestWriteConfigHonorsPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	c := config.New()
+	c.Set("port", 2000)
+	c.SetDefault("port", 3000)
+	if err := c.SetPrecedence(config.LayerDefaults, config.LayerFile); err != nil {
+		t.Fatalf("SetPrecedence() error = %v", err)
+	}
+	if err := c.WriteConfigAs(path); err != nil {
+		t.Fatalf("WriteConfigAs() error = %v", err)
+	}
+
+	r := config.New()
+	r.AddFile(path)
+	if err := r.ReadConfig(); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	if port := r.GetInt("port"); port != 3000 {
+		t.Errorf("port = %d, want %d", port, 3000)
+	}
+}
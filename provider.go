@@ -0,0 +1,372 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
+)
+
+// Provider is a source of configuration values. GetE asks every provider in
+// order of priority and returns the first value found.
+//
+// Lookup may be called from many goroutines at once, including while Load is
+// running, so implementations must be safe for concurrent use.
+type Provider interface {
+	// Name identifies the provider in logs and errors.
+	Name() string
+	// Load (re)loads the values of the provider. ReadConfig calls Load on
+	// every registered provider.
+	Load() error
+	// Lookup returns the value for the key and whether the provider has it.
+	Lookup(key Key) (any, bool)
+}
+
+// KeyLister is implemented by providers that can list their keys. AllKeys,
+// AllSettings and Query only see the keys of providers implementing it.
+type KeyLister interface {
+	// Keys returns the leaf keys the provider has values for.
+	Keys() []Key
+}
+
+// Priorities of the built-in providers with the default precedence. A provider
+// with a higher priority overrides the values of providers with lower
+// priorities. SetPrecedence hands out the same priorities, in this order, to
+// the layers it is given.
+const (
+	PriorityDefaults = 100
+	PriorityFile     = 200
+	PriorityEnv      = 300
+	PriorityFlags    = 400
+)
+
+// Layer identifies one of the built-in providers.
+type Layer int
+
+const (
+	// LayerFlags holds flags changed on the command line.
+	LayerFlags Layer = iota + 1
+	// LayerFlagDefaults holds the default values of flags. It is not part of
+	// the default precedence.
+	LayerFlagDefaults
+	// LayerEnv holds environment variables.
+	LayerEnv
+	// LayerFile holds values from config files and Set.
+	LayerFile
+	// LayerDefaults holds values from SetDefault.
+	LayerDefaults
+)
+
+// String returns the name of the layer, which is also the name of its
+// provider.
+func (l Layer) String() string {
+	switch l {
+	case LayerFlags:
+		return "flags"
+	case LayerFlagDefaults:
+		return "flag-defaults"
+	case LayerEnv:
+		return "env"
+	case LayerFile:
+		return "file"
+	case LayerDefaults:
+		return "defaults"
+	default:
+		return fmt.Sprintf("Layer(%d)", int(l))
+	}
+}
+
+// providerEntry is a registered provider and its priority. layer is zero for
+// custom providers.
+type providerEntry struct {
+	provider Provider
+	priority int
+	layer    Layer
+}
+
+// SetPrecedence sets the order in which the built-in layers are consulted,
+// from highest to lowest precedence. The default is
+//
+//	SetPrecedence(LayerFlags, LayerEnv, LayerFile, LayerDefaults)
+//
+// Layers that are left out are not consulted at all. The first layer gets
+// PriorityFlags, the second PriorityEnv and so on, each 100 lower than the
+// previous one, so custom providers keep their place between the slots.
+//
+// Example: make an explicit config file beat environment variables:
+//
+//	SetPrecedence(LayerFlags, LayerFile, LayerEnv, LayerDefaults)
+func (c *Config) SetPrecedence(layers ...Layer) error {
+	if len(layers) == 0 {
+		return errors.New("precedence must contain at least one layer")
+	}
+
+	entries := make([]providerEntry, 0, len(layers))
+	for i, layer := range layers {
+		p := c.layerProvider(layer)
+		if p == nil {
+			return fmt.Errorf("unknown layer: %v", layer)
+		}
+		if slices.ContainsFunc(entries, func(e providerEntry) bool { return e.layer == layer }) {
+			return fmt.Errorf("duplicate layer: %v", layer)
+		}
+		entries = append(entries, providerEntry{p, PriorityFlags - 100*i, layer})
+	}
+
+	return c.update(func(s *snapshot) error {
+		for e := range slices.Values(s.providers) {
+			if e.layer == 0 {
+				entries = append(entries, e)
+			}
+		}
+		slices.SortStableFunc(entries, compareProviders)
+		s.providers = entries
+		return nil
+	})
+}
+
+// layerProvider returns the built-in provider of the layer, or nil if the
+// layer is unknown. Views created by Sub use the providers of the parent.
+func (c *Config) layerProvider(l Layer) Provider {
+	if c.parent != nil {
+		if p := c.parent.layerProvider(l); p != nil {
+			return scopedProvider{p, c.root}
+		}
+		return nil
+	}
+	switch l {
+	case LayerFlags:
+		return flagProvider{c}
+	case LayerFlagDefaults:
+		return flagDefaultsProvider{c}
+	case LayerEnv:
+		return envProvider{c}
+	case LayerFile:
+		return fileProvider{c}
+	case LayerDefaults:
+		return defaultsProvider{c}
+	default:
+		return nil
+	}
+}
+
+// defaultProviders returns the built-in providers in the default precedence.
+func (c *Config) defaultProviders() []providerEntry {
+	return []providerEntry{
+		{flagProvider{c}, PriorityFlags, LayerFlags},
+		{envProvider{c}, PriorityEnv, LayerEnv},
+		{fileProvider{c}, PriorityFile, LayerFile},
+		{defaultsProvider{c}, PriorityDefaults, LayerDefaults},
+	}
+}
+
+// AddProvider registers a custom provider at the given priority. Use the
+// Priority constants to place it relative to the built-in providers; e.g.
+// PriorityEnv+1 places a provider between flags and environment variables.
+// Providers with equal priority are asked in registration order.
+//
+// The provider is loaded by the next call to ReadConfig.
+func (c *Config) AddProvider(p Provider, priority int) {
+	_ = c.update(func(s *snapshot) error {
+		s.providers = insertProvider(s.providers, providerEntry{p, priority, 0})
+		return nil
+	})
+}
+
+// insertProvider returns a copy of entries with e added, sorted by priority
+// from highest to lowest.
+func insertProvider(entries []providerEntry, e providerEntry) []providerEntry {
+	entries = append(slices.Clone(entries), e)
+	slices.SortStableFunc(entries, compareProviders)
+	return entries
+}
+
+// compareProviders orders providers by priority from highest to lowest.
+func compareProviders(a, b providerEntry) int {
+	return cmp.Compare(b.priority, a.priority)
+}
+
+// flagProvider looks up values in the flags added with AddPflag and the flag
+// set added with SetPflagSet. Only flags changed from their default count.
+type flagProvider struct{ c *Config }
+
+func (flagProvider) Name() string { return LayerFlags.String() }
+
+func (flagProvider) Load() error { return nil }
+
+func (p flagProvider) Lookup(key Key) (any, bool) {
+	s := p.c.load()
+	if flag, ok := s.pflags[key.Raw]; ok && flag.Changed {
+		return flag.Value.String(), true
+	}
+	if s.pflagSet != nil && s.pflagSet.Parsed() && s.pflagSet.Changed(key.Raw) {
+		return s.pflagSet.Lookup(key.Raw).Value.String(), true
+	}
+	return nil, false
+}
+
+func (p flagProvider) Keys() []Key {
+	s := p.c.load()
+	keys := []Key{}
+	for name, flag := range s.pflags {
+		if flag.Changed {
+			keys = appendKey(keys, name)
+		}
+	}
+	if s.pflagSet != nil && s.pflagSet.Parsed() {
+		s.pflagSet.Visit(func(f *pflag.Flag) { keys = appendKey(keys, f.Name) })
+	}
+	return keys
+}
+
+func (p flagProvider) Source(key Key) []string {
+	if flag, ok := p.c.load().pflags[key.Raw]; ok && flag.Changed {
+		return []string{"--" + flag.Name}
+	}
+	return []string{"--" + key.Raw}
+}
+
+// flagDefaultsProvider looks up the default values of the flags added with
+// AddPflag and SetPflagSet, whether or not they were changed.
+type flagDefaultsProvider struct{ c *Config }
+
+func (flagDefaultsProvider) Name() string { return LayerFlagDefaults.String() }
+
+func (flagDefaultsProvider) Load() error { return nil }
+
+func (p flagDefaultsProvider) Lookup(key Key) (any, bool) {
+	s := p.c.load()
+	if flag, ok := s.pflags[key.Raw]; ok {
+		return flag.DefValue, true
+	}
+	if s.pflagSet != nil {
+		if flag := s.pflagSet.Lookup(key.Raw); flag != nil {
+			return flag.DefValue, true
+		}
+	}
+	return nil, false
+}
+
+func (p flagDefaultsProvider) Keys() []Key {
+	s := p.c.load()
+	keys := []Key{}
+	for name := range s.pflags {
+		keys = appendKey(keys, name)
+	}
+	if s.pflagSet != nil {
+		s.pflagSet.VisitAll(func(f *pflag.Flag) { keys = appendKey(keys, f.Name) })
+	}
+	return keys
+}
+
+func (p flagDefaultsProvider) Source(key Key) []string {
+	if flag, ok := p.c.load().pflags[key.Raw]; ok {
+		return []string{"--" + flag.Name}
+	}
+	return []string{"--" + key.Raw}
+}
+
+// envProvider looks up values in environment variables named by Key.EnvKey
+// with the prefix set by SetEnvPrefix.
+type envProvider struct{ c *Config }
+
+func (envProvider) Name() string { return LayerEnv.String() }
+
+func (envProvider) Load() error { return nil }
+
+func (p envProvider) Lookup(key Key) (any, bool) {
+	return os.LookupEnv(key.EnvKey(p.c.load().envPrefix))
+}
+
+// Keys returns the keys of the environment variables starting with the prefix
+// set by SetEnvPrefix, reversing Key.EnvKey: "APP_DB__HOST" becomes "db.host"
+// and "APP_SERVERS___0__HOST" becomes "servers.0.host". Without a prefix no
+// keys are returned, as every variable of the environment would match.
+//
+// EnvKey is lossy, so a variable set for a key such as "dbHost" is listed as
+// "dbhost". AllKeys lists such variables under the keys of the other
+// providers when their EnvKey matches.
+func (p envProvider) Keys() []Key {
+	prefix := p.c.load().envPrefix
+	if prefix == "" {
+		return nil
+	}
+	prefix = strings.ToUpper(prefix) + "_"
+
+	keys := []Key{}
+	for env := range slices.Values(os.Environ()) {
+		name, _, _ := strings.Cut(env, "=")
+		rest, ok := strings.CutPrefix(name, prefix)
+		if !ok || rest == "" {
+			continue
+		}
+		parts := []KeyPart{}
+		for part := range strings.SplitSeq(rest, "__") {
+			if part == "" {
+				parts = nil
+				break
+			}
+			if digits, ok := strings.CutPrefix(part, "_"); ok && isDigits(digits) {
+				parts = append(parts, KeyPart{IndexKey, cast.ToInt(digits)})
+				continue
+			}
+			parts = append(parts, KeyPart{StringKey, strings.ToLower(part)})
+		}
+		if len(parts) != 0 {
+			keys = append(keys, Key{Raw: formatKey(parts), Parts: parts})
+		}
+	}
+	return keys
+}
+
+func (p envProvider) Source(key Key) []string {
+	return []string{key.EnvKey(p.c.load().envPrefix)}
+}
+
+// fileProvider looks up values loaded from config files and values set with
+// Set.
+type fileProvider struct{ c *Config }
+
+func (fileProvider) Name() string { return LayerFile.String() }
+
+func (p fileProvider) Load() error { return p.c.readConfigFiles() }
+
+func (p fileProvider) Lookup(key Key) (any, bool) {
+	v, err := p.c.getValue(p.c.load().config, key)
+	return v, err == nil
+}
+
+func (p fileProvider) Keys() []Key {
+	return leafKeys(p.c.load().config)
+}
+
+// Source returns the include chain of the file that set the key, or
+// "(set in code)" for values set with Set.
+func (p fileProvider) Source(key Key) []string {
+	chain, ok := lookupOrigin(p.c.load().origins, key)
+	if !ok || len(chain) == 0 {
+		return []string{"(set in code)"}
+	}
+	return chain
+}
+
+// defaultsProvider looks up values set with SetDefault.
+type defaultsProvider struct{ c *Config }
+
+func (defaultsProvider) Name() string { return LayerDefaults.String() }
+
+func (defaultsProvider) Load() error { return nil }
+
+func (p defaultsProvider) Lookup(key Key) (any, bool) {
+	v, err := p.c.getValue(p.c.load().defaults, key)
+	return v, err == nil
+}
+
+func (p defaultsProvider) Keys() []Key {
+	return leafKeys(p.c.load().defaults)
+}
@@ -0,0 +1,97 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+// mapProvider serves values from a map that is swapped on Load.
+type mapProvider struct {
+	mu     sync.RWMutex
+	name   string
+	next   map[string]any
+	values map[string]any
+	err    error
+	loads  int
+}
+
+func (p *mapProvider) Name() string { return p.name }
+
+func (p *mapProvider) Load() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.loads++
+	if p.err != nil {
+		return p.err
+	}
+	p.values = p.next
+	return nil
+}
+
+func (p *mapProvider) Lookup(key config.Key) (any, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	v, ok := p.values[key.Raw]
+	return v, ok
+}
+
+func TestAddProvider(t *testing.T) {
+	c := config.New()
+	c.SetEnvPrefix("PROVIDER")
+	c.Set("db.password", "from-file")
+	c.Set("db.user", "file-user")
+	c.SetDefault("db.host", "localhost")
+	_ = os.Setenv("PROVIDER_DB__USER", "env-user")
+	defer os.Unsetenv("PROVIDER_DB__USER")
+
+	secrets := &mapProvider{
+		name: "secrets",
+		next: map[string]any{
+			"db.password": "s3cret",
+			"db.user":     "secret-user",
+		},
+	}
+	c.AddProvider(secrets, config.PriorityFile+1)
+
+	if _, err := c.GetE("db.password"); err != nil {
+		t.Fatalf("GetE(\"db.password\") error = %v", err)
+	}
+	if v := c.GetString("db.password"); v != "from-file" {
+		t.Fatalf("db.password before Load = %q, want %q", v, "from-file")
+	}
+
+	// No config files are registered, so only the file provider fails.
+	c.ReadConfig()
+	if secrets.loads != 1 {
+		t.Fatalf("provider loaded %d times, want 1", secrets.loads)
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"db.password", "s3cret"},
+		{"db.user", "env-user"},
+		{"db.host", "localhost"},
+	}
+	for _, tt := range tests {
+		if v := c.GetString(tt.key); v != tt.want {
+			t.Errorf("GetString(%q) = %q, want %q", tt.key, v, tt.want)
+		}
+	}
+}
+
+func TestProviderLoadError(t *testing.T) {
+	c := config.New()
+	loadErr := errors.New("connection refused")
+	c.AddProvider(&mapProvider{name: "secrets", err: loadErr}, config.PriorityFlags+1)
+
+	err := c.ReadConfig()
+	if !errors.Is(err, loadErr) {
+		t.Fatalf("ReadConfig() error = %v, want %v", err, loadErr)
+	}
+}
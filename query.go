@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"reflect"
+	"slices"
+)
+
+// Query returns every concrete key matching pattern along with its value.
+// The pattern uses the KeySplit grammar, where each part may be a glob as
+// understood by path.Match and "**" matches zero or more parts.
+//
+// Candidate keys are those of AllKeys and their parents, and each value is
+// resolved with GetE, so the priorities of the providers apply.
+//
+// Example:
+//
+//	servers.*.host     → servers.0.host, servers.1.host
+//	plugins.**.enabled → plugins.enabled, plugins.a.enabled, plugins.a.b.enabled
+//	db_*               → db_host, db_port
+func (c *Config) Query(pattern string) (map[string]any, error) {
+	parsed, err := KeySplit(pattern)
+	if err != nil {
+		return nil, err
+	}
+	for part := range slices.Values(parsed.Parts) {
+		if part.Kind != StringKey {
+			continue
+		}
+		if _, err := path.Match(part.String(), ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", part.String(), err)
+		}
+	}
+
+	out := map[string]any{}
+	for key := range slices.Values(c.candidateKeys()) {
+		if !matchKey(parsed.Parts, key) {
+			continue
+		}
+		name := formatKey(key)
+		if _, ok := out[name]; ok {
+			continue
+		}
+		v, err := c.GetE(name)
+		if err != nil {
+			c.GetLogger().Debug("Failed to resolve queried key", "key", name, "error", err)
+			continue
+		}
+		out[name] = v
+	}
+	return out, nil
+}
+
+// candidateKeys returns the parts of every key of AllKeys along with the
+// parts of their parents.
+func (c *Config) candidateKeys() [][]KeyPart {
+	keys := [][]KeyPart{}
+	for key := range maps.Values(c.allKeys()) {
+		for i := 1; i <= key.Len(); i++ {
+			keys = append(keys, key.Parts[:i])
+		}
+	}
+	return keys
+}
+
+// walkKeys calls fn for every value nested below v with the parts of its key.
+// Maps with string keys and slices are descended into.
+func walkKeys(v any, prefix []KeyPart, fn func([]KeyPart, any)) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case isStringKeyMap(rv):
+		for k, val := range toStringAnyMap(rv) {
+			parts := append(slices.Clip(prefix), KeyPart{StringKey, k})
+			fn(parts, val)
+			walkKeys(val, parts, fn)
+		}
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array:
+		for i := range rv.Len() {
+			val := rv.Index(i).Interface()
+			parts := append(slices.Clip(prefix), KeyPart{IndexKey, i})
+			fn(parts, val)
+			walkKeys(val, parts, fn)
+		}
+	}
+}
+
+// matchKey reports whether key matches the pattern parts. A "**" part matches
+// zero or more key parts.
+func matchKey(pattern, key []KeyPart) bool {
+	if len(pattern) == 0 {
+		return len(key) == 0
+	}
+
+	head := pattern[0]
+	if head.Kind == StringKey && head.String() == "**" {
+		for i := 0; i <= len(key); i++ {
+			if matchKey(pattern[1:], key[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(key) == 0 || !matchPart(head, key[0]) {
+		return false
+	}
+	return matchKey(pattern[1:], key[1:])
+}
+
+func matchPart(pattern, part KeyPart) bool {
+	if pattern.Kind == IndexKey {
+		return pattern.String() == part.String()
+	}
+	ok, _ := path.Match(pattern.String(), part.String())
+	return ok
+}
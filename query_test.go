@@ -0,0 +1,84 @@
+package config_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+func TestQuery(t *testing.T) {
+	c := config.New()
+	c.Set("servers", []any{
+		map[string]any{"host": "a.example.com"},
+		map[string]any{"host": "b.example.com"},
+	})
+	c.Set("plugins.enabled", true)
+	c.Set("plugins.auth.enabled", false)
+	c.Set("plugins.auth.ldap.enabled", true)
+	c.SetDefault("plugins.cache.enabled", true)
+	c.SetDefault("db_host", "localhost")
+	c.SetDefault("db_port", 5432)
+	c.SetEnvPrefix("QUERY")
+	_ = os.Setenv("QUERY_DB_HOST", "db.example.com")
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name:    "wildcard index",
+			pattern: "servers.*.host",
+			want: map[string]any{
+				"servers.0.host": "a.example.com",
+				"servers.1.host": "b.example.com",
+			},
+		},
+		{
+			name:    "recursive wildcard",
+			pattern: "plugins.**.enabled",
+			want: map[string]any{
+				"plugins.enabled":           true,
+				"plugins.auth.enabled":      false,
+				"plugins.auth.ldap.enabled": true,
+				"plugins.cache.enabled":     true,
+			},
+		},
+		{
+			name:    "glob respects env layer",
+			pattern: "db_*",
+			want: map[string]any{
+				"db_host": "db.example.com",
+				"db_port": 5432,
+			},
+		},
+		{
+			name:    "no match",
+			pattern: "missing.*",
+			want:    map[string]any{},
+		},
+		{
+			name:    "invalid glob",
+			pattern: "'db_[a'",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.Query(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Query(%q) error = %v, wantErr %v", tt.pattern, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Query(%q):\nGot: %s\nWant: %s", tt.pattern, JSON(got), JSON(tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// RuleContext is passed to validation rules registered with RegisterRule.
+type RuleContext struct {
+	// Field is the struct field being validated.
+	Field reflect.StructField
+	// Value is the value of the field. It is settable, so rules may
+	// normalize it.
+	Value reflect.Value
+	// Param is the argument of the rule, such as "2" for `check:"semver=2"`,
+	// or empty if the rule has none.
+	Param string
+	// Parent is the struct holding the field. It is invalid when Validate is
+	// called directly.
+	Parent reflect.Value
+	// Key is the config key of the field, such as "server.tls.cert". It is
+	// empty when Validate is called directly.
+	Key string
+	// Changed reports whether the field was set from the configuration.
+	Changed bool
+
+	// config is the Config being bound, or nil when Validate is called
+	// directly.
+	config *Config
+}
+
+// builtinRules are the rules handled by validateRule itself.
+var builtinRules = map[string]bool{
+	"required":     true,
+	"default":      true,
+	"enum":         true,
+	"base64":       true,
+	"email":        true,
+	"uuid":         true,
+	"alpha":        true,
+	"alphanumeric": true,
+	"number":       true,
+	"match":        true,
+	"min":          true,
+	"max":          true,
+	"dive":         true,
+	"keys":         true,
+	"endkeys":      true,
+	"after":        true,
+	"before":       true,
+	"url":          true,
+	"hostname":     true,
+	"ip":           true,
+	"ipv4":         true,
+	"ipv6":         true,
+	"cidr":         true,
+	"port":         true,
+	"hostport":     true,
+	"mac":          true,
+	"expand":       true,
+	"abs":          true,
+	"exists":       true,
+	"file":         true,
+	"dir":          true,
+	"readable":     true,
+	"writable":     true,
+	"executable":   true,
+	"trim":         true,
+	"lower":        true,
+	"upper":        true,
+	"title":        true,
+	"expandenv":    true,
+	"truncate":     true,
+	"clamp":        true,
+}
+
+// customRules holds the rules registered with RegisterRule.
+var customRules = struct {
+	sync.RWMutex
+	m map[string]func(ctx RuleContext) error
+}{m: map[string]func(ctx RuleContext) error{}}
+
+// RegisterRule registers a validation rule usable in "check" tags alongside
+// the built-in rules, on top-level and nested fields alike. Registering a
+// name again replaces the previous rule. RegisterRule panics if the name is
+// empty, contains ',' or '=', or is a built-in rule, or if fn is nil.
+//
+// fn returns an error if the value is invalid. ValidateTags calls fn with the
+// zero value of the field to find invalid tags, so fn should return a TagError
+// for a parameter or a field type it doesn't support.
+//
+// Example:
+//
+//	config.RegisterRule("semver", func(ctx config.RuleContext) error {
+//		if ctx.Value.Kind() != reflect.String {
+//			return config.TagError{Err: errors.New("semver must be a string")}
+//		}
+//		if !semver.IsValid(ctx.Value.String()) {
+//			return fmt.Errorf("%q is not a valid version", ctx.Value.String())
+//		}
+//		return nil
+//	})
+func RegisterRule(name string, fn func(ctx RuleContext) error) {
+	switch {
+	case name == "" || strings.ContainsAny(name, ",="):
+		panic(fmt.Sprintf("invalid validation rule name %q", name))
+	case builtinRules[name] || crossFieldRules[name]:
+		panic(fmt.Sprintf("validation rule %q is built in", name))
+	case fn == nil:
+		panic(fmt.Sprintf("validation rule %q is nil", name))
+	}
+
+	customRules.Lock()
+	defer customRules.Unlock()
+	customRules.m[name] = fn
+}
+
+// lookupRule returns the rule registered with RegisterRule.
+func lookupRule(name string) (func(ctx RuleContext) error, bool) {
+	customRules.RLock()
+	defer customRules.RUnlock()
+	fn, ok := customRules.m[name]
+	return fn, ok
+}
@@ -0,0 +1,145 @@
+package config_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+var semverRegexp = regexp.MustCompile(`^v?(\d+)\.\d+\.\d+$`)
+
+func init() {
+	config.RegisterRule("semver", func(ctx config.RuleContext) error {
+		if ctx.Value.Kind() != reflect.String {
+			return config.TagError{Err: errors.New("semver must be a string")}
+		}
+		if ctx.Param != "" && ctx.Param != "v" {
+			return config.TagError{Err: fmt.Errorf("invalid semver prefix %q", ctx.Param)}
+		}
+		s := ctx.Value.String()
+		if s == "" {
+			return nil
+		}
+		if !semverRegexp.MatchString(s) {
+			return fmt.Errorf("%q is not a semantic version", s)
+		}
+		if ctx.Param == "v" && !strings.HasPrefix(s, "v") {
+			return fmt.Errorf("%q must start with v", s)
+		}
+		return nil
+	})
+}
+
+func TestRegisterRule(t *testing.T) {
+	type Plugin struct {
+		Name    string `config:"name"`
+		Version string `config:"version" check:"semver=v"`
+	}
+	type App struct {
+		Version string `config:"version" check:"required,semver"`
+		Plugin  Plugin `config:"plugin"`
+	}
+
+	c := config.New()
+	c.Set("app.version", "1.2.3")
+	c.Set("app.plugin.name", "auth")
+	c.Set("app.plugin.version", "v0.4.1")
+
+	var app App
+	if err := c.Bind("app", &app); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	c.Set("app.version", "1.2")
+	c.Set("app.plugin.version", "0.4.1")
+	err := c.Bind("app", &app)
+
+	var errs config.BindErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Bind() error = %v, want BindErrors", err)
+	}
+	got := map[string]string{}
+	for _, e := range errs {
+		got[e.Key] = e.Rule
+	}
+	want := map[string]string{"app.version": "semver", "app.plugin.version": "semver"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Bind() errors = %v, want %v", got, want)
+	}
+}
+
+func TestRuleContext(t *testing.T) {
+	var got config.RuleContext
+	config.RegisterRule("capture", func(ctx config.RuleContext) error {
+		got = ctx
+		return nil
+	})
+
+	type Server struct {
+		Host string `config:"host"`
+		Port int    `config:"port" check:"capture=tcp"`
+	}
+	type App struct {
+		Server Server `config:"server"`
+	}
+
+	c := config.New()
+	c.Set("app.server.host", "localhost")
+	c.Set("app.server.port", 8080)
+
+	var app App
+	if err := c.Bind("app", &app); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	if got.Key != "app.server.port" {
+		t.Errorf("Key = %q, want app.server.port", got.Key)
+	}
+	if got.Param != "tcp" {
+		t.Errorf("Param = %q, want tcp", got.Param)
+	}
+	if got.Field.Name != "Port" || got.Value.Int() != 8080 || !got.Changed {
+		t.Errorf("Field = %s, Value = %v, Changed = %v", got.Field.Name, got.Value, got.Changed)
+	}
+	if got.Parent.Type() != reflect.TypeFor[Server]() ||
+		got.Parent.FieldByName("Host").String() != "localhost" {
+		t.Errorf("Parent = %v, want the Server struct", got.Parent)
+	}
+}
+
+func TestRegisterRuleTags(t *testing.T) {
+	type Invalid struct {
+		Count  int    `check:"semver"`
+		Prefix string `check:"semver=x"`
+		Valid  string `check:"semver=v"`
+	}
+
+	err := config.ValidateTags(reflect.TypeFor[Invalid]())
+	if err == nil {
+		t.Fatal("ValidateTags() should fail")
+	}
+	for _, field := range []string{"Count", "Prefix"} {
+		if !strings.Contains(err.Error(), "on "+field+":") {
+			t.Errorf("ValidateTags() error = %v, want %s", err, field)
+		}
+	}
+	if strings.Contains(err.Error(), "on Valid:") {
+		t.Errorf("ValidateTags() error = %v, want Valid accepted", err)
+	}
+
+	for _, name := range []string{"", "min", "a,b"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterRule(%q) should panic", name)
+				}
+			}()
+			config.RegisterRule(name, func(config.RuleContext) error { return nil })
+		}()
+	}
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"cmp"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// AllKeys returns the sorted leaf keys known to any provider, such as
+// "app.port" or "servers.0.host". Keys are collected from every provider
+// implementing KeyLister; the built-in providers list changed flags,
+// environment variables with the prefix set by SetEnvPrefix, config files and
+// defaults.
+//
+// An environment variable is listed under the key of another provider when
+// its name matches that key's EnvKey, so "APP_DBHOST" is listed as "dbHost"
+// if a config file sets "dbHost".
+func (c *Config) AllKeys() []string {
+	return slices.Sorted(maps.Keys(c.allKeys()))
+}
+
+// AllSettings returns the fully resolved configuration as a nested map. Every
+// key of AllKeys is resolved with GetE, so the result holds exactly the values
+// the getters return, across flags, environment variables, config files and
+// defaults.
+//
+// Keys with an index part, like "servers.0.host", are stored in slices.
+func (c *Config) AllSettings() map[string]any {
+	keys := c.allKeys()
+	out := map[string]any{}
+	// Keys are sorted by their parts, so list elements are set in order
+	names := slices.SortedFunc(maps.Keys(keys), func(a, b string) int {
+		return compareParts(keys[a].Parts, keys[b].Parts)
+	})
+	for name := range slices.Values(names) {
+		v, err := c.GetE(name)
+		if err != nil {
+			c.GetLogger().Debug("Failed to resolve key", "key", name, "error", err)
+			continue
+		}
+		next, err := setPath(out, keys[name].Parts, deepCopy(v))
+		if err != nil {
+			c.GetLogger().Debug("Failed to set key", "key", name, "error", err)
+			continue
+		}
+		out = next.(map[string]any)
+	}
+	return out
+}
+
+// compareParts orders keys part by part, indexes numerically and before names.
+func compareParts(a, b []KeyPart) int {
+	for i := range min(len(a), len(b)) {
+		pa, pb := a[i], b[i]
+		var c int
+		switch {
+		case pa.Kind == IndexKey && pb.Kind == IndexKey:
+			c = cmp.Compare(pa.Int(), pb.Int())
+		case pa.Kind == IndexKey:
+			c = -1
+		case pb.Kind == IndexKey:
+			c = 1
+		default:
+			c = cmp.Compare(pa.String(), pb.String())
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// allKeys returns the leaf keys of every provider implementing KeyLister,
+// mapped by their formatted name.
+func (c *Config) allKeys() map[string]Key {
+	s := c.load()
+	keys := map[string]Key{}
+	envKeys := map[string]bool{}
+	var fromEnv []Key
+
+	for e := range slices.Values(s.providers) {
+		lister, ok := e.provider.(KeyLister)
+		if !ok {
+			continue
+		}
+		if e.layer == LayerEnv {
+			fromEnv = lister.Keys()
+			continue
+		}
+		for key := range slices.Values(lister.Keys()) {
+			keys[formatKey(key.Parts)] = key
+			envKeys[key.EnvKey(s.envPrefix)] = true
+		}
+	}
+
+	// Environment variables are matched last, so known keys keep their case.
+	for key := range slices.Values(fromEnv) {
+		if !envKeys[key.EnvKey(s.envPrefix)] {
+			keys[formatKey(key.Parts)] = key
+		}
+	}
+	return keys
+}
+
+// leafKeys returns the keys of every value nested in m that is not a
+// non-empty map or slice.
+func leafKeys(m map[string]any) []Key {
+	keys := []Key{}
+	walkKeys(m, nil, func(parts []KeyPart, v any) {
+		rv := reflect.ValueOf(v)
+		switch {
+		case isStringKeyMap(rv), rv.Kind() == reflect.Slice, rv.Kind() == reflect.Array:
+			if rv.Len() != 0 {
+				return
+			}
+		}
+		keys = append(keys, Key{Raw: formatKey(parts), Parts: parts})
+	})
+	return keys
+}
+
+// appendKey appends the parsed name to keys, skipping names KeySplit rejects.
+func appendKey(keys []Key, name string) []Key {
+	parsed, err := KeySplit(name)
+	if err != nil {
+		return keys
+	}
+	return append(keys, parsed)
+}
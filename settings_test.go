@@ -0,0 +1,114 @@
+package config_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+	"github.com/spf13/pflag"
+)
+
+func newAllSettingsConfig(t *testing.T) *config.Config {
+	t.Helper()
+
+	env := map[string]string{
+		"ALLSET_APP__PORT":          "9000",
+		"ALLSET_DBHOST":             "db.example.com",
+		"ALLSET_CACHE__TTL":         "60",
+		"ALLSET_SERVERS___1__HOST":  "b.example.com",
+		"ALLSETTINGS_IGNORED__PORT": "1",
+	}
+	for k, v := range env {
+		_ = os.Setenv(k, v)
+	}
+	t.Cleanup(func() {
+		for k := range env {
+			_ = os.Unsetenv(k)
+		}
+	})
+
+	c := config.New()
+	c.SetEnvPrefix("ALLSET")
+	c.Set("app.port", 8080)
+	c.Set("app.name", "MyApp")
+	c.Set("dbHost", "localhost")
+	c.Set("servers", []any{map[string]any{"host": "a.example.com"}})
+	c.SetDefault("app.env", "dev")
+	c.SetDefault("app.port", 80)
+
+	fs := pflag.NewFlagSet("app", pflag.ContinueOnError)
+	fs.String("log-level", "info", "")
+	fs.Bool("verbose", false, "")
+	if err := fs.Parse([]string{"--log-level=debug"}); err != nil {
+		t.Fatal(err)
+	}
+	c.SetPflagSet(fs)
+	return c
+}
+
+func TestAllKeys(t *testing.T) {
+	c := newAllSettingsConfig(t)
+
+	want := []string{
+		"app.env",
+		"app.name",
+		"app.port",
+		"cache.ttl",
+		"dbHost",
+		"log-level",
+		"servers.0.host",
+		"servers.1.host",
+	}
+	if got := c.AllKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AllKeys():\nGot: %v\nWant: %v", got, want)
+	}
+}
+
+func TestAllSettings(t *testing.T) {
+	c := newAllSettingsConfig(t)
+
+	want := map[string]any{
+		"app": map[string]any{
+			"env":  "dev",
+			"name": "MyApp",
+			"port": "9000",
+		},
+		"cache":     map[string]any{"ttl": "60"},
+		"dbHost":    "db.example.com",
+		"log-level": "debug",
+		"servers": []any{
+			map[string]any{"host": "a.example.com"},
+			map[string]any{"host": "b.example.com"},
+		},
+	}
+	if got := c.AllSettings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AllSettings():\nGot: %#v\nWant: %#v", got, want)
+	}
+
+	var app struct {
+		Port int    `config:"port"`
+		Env  string `config:"env"`
+	}
+	r := config.New()
+	r.Set(".", c.AllSettings())
+	if err := r.Bind("app", &app); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if app.Port != 9000 || app.Env != "dev" {
+		t.Errorf("bound app = %+v, want port 9000 and env dev", app)
+	}
+}
+
+func TestQueryIncludesEnv(t *testing.T) {
+	c := newAllSettingsConfig(t)
+
+	got, err := c.Query("cache.*")
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	want := map[string]any{"cache.ttl": "60"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Query(\"cache.*\") = %v, want %v", got, want)
+	}
+}
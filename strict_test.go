@@ -0,0 +1,95 @@
+package config_test
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+func TestReadConfigStrict(t *testing.T) {
+	dir := t.TempDir()
+	main := filepath.Join(dir, "config.yaml")
+	broken := filepath.Join(dir, "broken.json")
+	cycle := filepath.Join(dir, "cycle.yaml")
+	missing := filepath.Join(dir, "missing.yaml")
+
+	writeFile(t, main, "include: [broken.json, missing.yaml, cycle.yaml]\napp:\n  port: 8080\n")
+	writeFile(t, broken, `{"app": {"name": }`)
+	writeFile(t, cycle, "include: config.yaml\napp:\n  env: dev\n")
+
+	c := config.New()
+	c.AddFile(main)
+	if err := c.ReadConfig(); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	if port := c.GetInt("app.port"); port != 8080 {
+		t.Fatalf("app.port = %d, want %d", port, 8080)
+	}
+
+	writeFile(t, main, "include: [broken.json, missing.yaml, cycle.yaml]\napp:\n  port: 9090\n")
+	c.SetStrict(true)
+	err := c.ReadConfig()
+	if err == nil {
+		t.Fatal("strict ReadConfig() should fail")
+	}
+
+	fileErrs := collectErrors[config.FileError](err)
+	parseErrs := collectErrors[config.ParseError](err)
+	cycleErrs := collectErrors[config.CycleError](err)
+	if len(cycleErrs) != 1 {
+		t.Fatalf("got %d CycleErrors, want 1: %v", len(cycleErrs), err)
+	}
+	cycleErr := cycleErrs[0]
+
+	if len(parseErrs) != 1 {
+		t.Fatalf("got %d ParseErrors, want 1: %v", len(parseErrs), err)
+	}
+	if parseErrs[0].Path != broken || !slices.Equal(parseErrs[0].IncludedFrom, []string{main}) {
+		t.Errorf("ParseError = %+v, want %s included from %s", parseErrs[0], broken, main)
+	}
+
+	if len(fileErrs) != 1 {
+		t.Fatalf("got %d FileErrors, want 1: %v", len(fileErrs), err)
+	}
+	if fileErrs[0].Path != missing || !errors.Is(fileErrs[0], fs.ErrNotExist) {
+		t.Errorf("FileError = %+v, want missing %s", fileErrs[0], missing)
+	}
+	if want := []string{main, cycle, main}; !slices.Equal(cycleErr.Chain, want) {
+		t.Errorf("CycleError.Chain = %v, want %v", cycleErr.Chain, want)
+	}
+
+	if port := c.GetInt("app.port"); port != 8080 {
+		t.Errorf("app.port = %d after failed strict ReadConfig, want previous %d", port, 8080)
+	}
+}
+
+func TestReadConfigNoConfig(t *testing.T) {
+	c := config.New()
+	c.AddPath(t.TempDir())
+	if err := c.ReadConfig(); !errors.Is(err, config.ErrNoConfig) {
+		t.Errorf("ReadConfig() error = %v, want %v", err, config.ErrNoConfig)
+	}
+}
+
+// collectErrors returns every error of type T in the tree of err.
+func collectErrors[T error](err error) []T {
+	var out []T
+	if e, ok := err.(T); ok {
+		out = append(out, e)
+	}
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		for e := range slices.Values(u.Unwrap()) {
+			out = append(out, collectErrors[T](e)...)
+		}
+	case interface{ Unwrap() error }:
+		if e := u.Unwrap(); e != nil {
+			out = append(out, collectErrors[T](e)...)
+		}
+	}
+	return out
+}
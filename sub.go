@@ -0,0 +1,143 @@
+package config
+
+import (
+	"slices"
+)
+
+// Sub returns a view of the configuration rooted at key, so that
+// c.Sub("plugins.cache").GetInt("size") returns c.GetInt("plugins.cache.size").
+// It returns nil if the key is invalid.
+//
+// The view is live: every provider of c is consulted with the key prefixed, so
+// flags, defaults and environment variables apply under their full names; with
+// the env prefix "APP", the view's key "size" is read from
+// APP_PLUGINS__CACHE__SIZE. Set and SetDefault on the view write to c.
+//
+// WriteConfigAs on the view writes the section of the config files and
+// defaults of c below the key. WriteConfig on the view returns an error.
+//
+// The providers of c are copied when Sub is called. Providers added to the
+// view, and SetPrecedence on the view, only affect the view. Files, flags and
+// the env prefix stay with c.
+func (c *Config) Sub(key string) *Config {
+	root := []KeyPart{}
+	if key != "." {
+		parsed, err := KeySplit(key)
+		if err != nil {
+			return nil
+		}
+		root = parsed.Parts
+	}
+
+	sub := New()
+	sub.parent = c
+	sub.root = root
+	if l := c.logger.Load(); l != nil {
+		sub.logger.Store(l)
+	}
+
+	providers := slices.Clone(c.load().providers)
+	for i, e := range providers {
+		providers[i].provider = scopedProvider{e.provider, root}
+	}
+	_ = sub.update(func(s *snapshot) error {
+		s.providers = providers
+		return nil
+	})
+	return sub
+}
+
+// parentKey returns the key of the parent for a key of a view created by Sub.
+func (c *Config) parentKey(key string) (string, error) {
+	if key == "." {
+		if len(c.root) == 0 {
+			return ".", nil
+		}
+		return formatKey(c.root), nil
+	}
+	parsed, err := KeySplit(key)
+	if err != nil {
+		return "", err
+	}
+	return formatKey(slices.Concat(c.root, parsed.Parts)), nil
+}
+
+// fileSettings returns the values loaded from config files and Set. For a
+// view created by Sub it is the section of the parent below the root.
+func (c *Config) fileSettings() map[string]any {
+	return c.section(func(s *snapshot) map[string]any { return s.config })
+}
+
+// defaultSettings returns the values set with SetDefault. For a view created
+// by Sub it is the section of the parent below the root.
+func (c *Config) defaultSettings() map[string]any {
+	return c.section(func(s *snapshot) map[string]any { return s.defaults })
+}
+
+// section returns the map of the root snapshot chosen by layer, narrowed to
+// the root of the view.
+func (c *Config) section(layer func(*snapshot) map[string]any) map[string]any {
+	if c.parent == nil {
+		return layer(c.load())
+	}
+	m := c.parent.section(layer)
+	if len(c.root) == 0 {
+		return m
+	}
+	v, err := c.parent.getValue(m, Key{Raw: formatKey(c.root), Parts: c.root})
+	if err != nil {
+		return map[string]any{}
+	}
+	if section, ok := v.(map[string]any); ok {
+		return section
+	}
+	return map[string]any{}
+}
+
+// scopedProvider looks up keys of a view created by Sub in a provider of the
+// parent, with the root of the view prepended.
+type scopedProvider struct {
+	Provider
+	root []KeyPart
+}
+
+func (p scopedProvider) Lookup(key Key) (any, bool) {
+	return p.Provider.Lookup(p.key(key))
+}
+
+func (p scopedProvider) Source(key Key) []string {
+	if sp, ok := p.Provider.(SourceProvider); ok {
+		return sp.Source(p.key(key))
+	}
+	return nil
+}
+
+// Keys returns the keys of the wrapped provider below the root, with the root
+// removed.
+func (p scopedProvider) Keys() []Key {
+	lister, ok := p.Provider.(KeyLister)
+	if !ok {
+		return nil
+	}
+	keys := []Key{}
+	for key := range slices.Values(lister.Keys()) {
+		if key.Len() <= len(p.root) || !slices.Equal(key.Parts[:len(p.root)], p.root) {
+			continue
+		}
+		parts := key.Parts[len(p.root):]
+		keys = append(keys, Key{Raw: formatKey(parts), Parts: parts})
+	}
+	return keys
+}
+
+// key returns the key of the parent for key.
+func (p scopedProvider) key(key Key) Key {
+	if len(p.root) == 0 {
+		return key
+	}
+	if key.Raw == "." {
+		return Key{Raw: formatKey(p.root), Parts: p.root}
+	}
+	parts := slices.Concat(p.root, key.Parts)
+	return Key{Raw: formatKey(parts), Parts: parts}
+}
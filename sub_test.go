@@ -0,0 +1,112 @@
+package config_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+	"github.com/spf13/pflag"
+)
+
+func TestSub(t *testing.T) {
+	_ = os.Setenv("SUB_PLUGINS__CACHE__TTL", "60")
+	defer os.Unsetenv("SUB_PLUGINS__CACHE__TTL")
+
+	c := config.New()
+	c.SetEnvPrefix("SUB")
+	c.Set("plugins.cache.size", 128)
+	c.Set("plugins.cache.backends", []any{"redis", "memory"})
+	c.Set("plugins.auth.enabled", true)
+	c.SetDefault("plugins.cache.enabled", true)
+
+	fs := pflag.NewFlagSet("app", pflag.ContinueOnError)
+	fs.String("plugins.cache.mode", "lru", "")
+	if err := fs.Parse([]string{"--plugins.cache.mode=lfu"}); err != nil {
+		t.Fatal(err)
+	}
+	c.SetPflagSet(fs)
+
+	sub := c.Sub("plugins.cache")
+	if sub == nil {
+		t.Fatal("Sub() returned nil")
+	}
+
+	tests := []struct {
+		key  string
+		want any
+	}{
+		{key: "size", want: 128},
+		{key: "backends[1]", want: "memory"},
+		{key: "ttl", want: "60"},
+		{key: "mode", want: "lfu"},
+		{key: "enabled", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := sub.GetE(tt.key)
+			if err != nil {
+				t.Fatalf("GetE(%q) error = %v", tt.key, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetE(%q) = %#v, want %#v", tt.key, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := sub.GetE("auth.enabled"); err == nil {
+		t.Error("GetE(\"auth.enabled\") should not see keys outside of the view")
+	}
+
+	wantKeys := []string{"backends.0", "backends.1", "enabled", "mode", "size", "ttl"}
+	if got := sub.AllKeys(); !reflect.DeepEqual(got, wantKeys) {
+		t.Errorf("AllKeys() = %v, want %v", got, wantKeys)
+	}
+
+	wantSettings := map[string]any{"size": 128, "backends": []any{"redis", "memory"}}
+	if got := sub.Settings(); !reflect.DeepEqual(got, wantSettings) {
+		t.Errorf("Settings() = %v, want %v", got, wantSettings)
+	}
+}
+
+func TestSubIsLive(t *testing.T) {
+	c := config.New()
+	sub := c.Sub("plugins.cache")
+
+	c.Set("plugins.cache.size", 64)
+	if size := sub.GetInt("size"); size != 64 {
+		t.Errorf("size = %d after parent Set, want %d", size, 64)
+	}
+
+	sub.Set("size", 256)
+	sub.SetDefault("ttl", 30)
+	if size := c.GetInt("plugins.cache.size"); size != 256 {
+		t.Errorf("parent size = %d after Sub Set, want %d", size, 256)
+	}
+	if ttl := c.GetInt("plugins.cache.ttl"); ttl != 30 {
+		t.Errorf("parent ttl = %d after Sub SetDefault, want %d", ttl, 30)
+	}
+
+	nested := sub.Sub("redis")
+	nested.Set("host", "localhost")
+	if host := c.GetString("plugins.cache.redis.host"); host != "localhost" {
+		t.Errorf("parent host = %q after nested Sub Set, want %q", host, "localhost")
+	}
+
+	var cache struct {
+		Size int `config:"size"`
+		TTL  int `config:"ttl"`
+	}
+	if err := sub.Bind("", &cache); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if cache.Size != 256 || cache.TTL != 30 {
+		t.Errorf("bound cache = %+v, want size 256 and ttl 30", cache)
+	}
+}
+
+func TestSubInvalidKey(t *testing.T) {
+	if sub := config.New().Sub("plugins.'cache"); sub != nil {
+		t.Error("Sub() with an invalid key should return nil")
+	}
+}
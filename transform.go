@@ -0,0 +1,161 @@
+package config
+
+import (
+	"cmp"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spf13/cast"
+)
+
+// transformRules are the rules that set or change the value of a field
+// instead of checking it. They run before the other rules, in the order of the
+// tag, so `check:"trim,lower,enum='a,b'"` checks the trimmed, lowercase value
+// and `check:"min=1,default=5"` checks the default of an unset field.
+var transformRules = map[string]bool{
+	"default":   true,
+	"trim":      true,
+	"lower":     true,
+	"upper":     true,
+	"title":     true,
+	"expandenv": true,
+	"expand":    true,
+	"truncate":  true,
+	"clamp":     true,
+}
+
+// transformString applies the trim, lower, upper, title and expandenv rules.
+func transformString(sfv reflect.Value, name string) error {
+	value, err := stringValue(name, sfv)
+	if err != nil || !value.IsValid() {
+		return err
+	}
+
+	str := value.String()
+	switch name {
+	case "trim":
+		str = strings.TrimSpace(str)
+	case "lower":
+		str = strings.ToLower(str)
+	case "upper":
+		str = strings.ToUpper(str)
+	case "title":
+		str = titleCase(str)
+	case "expandenv":
+		str = os.ExpandEnv(str)
+	}
+	value.SetString(str)
+	return nil
+}
+
+// titleCase maps the first letter of each word of s to title case. Words are
+// separated by anything but letters, digits and apostrophes.
+func titleCase(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	start := true
+	for _, r := range s {
+		if start {
+			b.WriteRune(unicode.ToTitle(r))
+		} else {
+			b.WriteRune(r)
+		}
+		start = !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	}
+	return b.String()
+}
+
+// transformTruncate applies the truncate rule: strings are cut to at most N
+// characters, slices to at most N elements.
+func transformTruncate(sfv reflect.Value, rule any) error {
+	n, err := ruleParam(rule, cast.ToIntE)
+	if err != nil {
+		return err
+	}
+	if n < 0 {
+		return tagErrorf("truncate needs a length of at least 0, got %d", n)
+	}
+
+	value := resolvePointer(sfv)
+	switch value.Kind() {
+	case reflect.Invalid:
+		// nil pointer
+	case reflect.String:
+		str := value.String()
+		if utf8.RuneCountInString(str) > n {
+			value.SetString(string([]rune(str)[:n]))
+		}
+	case reflect.Slice:
+		if value.Len() > n {
+			value.Set(value.Slice(0, n))
+		}
+	default:
+		return tagErrorf("%s does not support truncate", value.Kind())
+	}
+	return nil
+}
+
+// transformClamp applies the clamp rule: numbers and durations are limited to
+// the range of the parameter, such as clamp=1..100 or clamp=1s..1m.
+func transformClamp(sfv reflect.Value, rule any) error {
+	value := resolvePointer(sfv)
+	switch {
+	case !value.IsValid():
+		// nil pointer
+	case value.Type() == reflect.TypeOf(time.Duration(0)):
+		lo, hi, err := clampRange(rule, cast.ToDurationE)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(min(max(time.Duration(value.Int()), lo), hi)))
+	case value.CanInt():
+		lo, hi, err := clampRange(rule, cast.ToInt64E)
+		if err != nil {
+			return err
+		}
+		if value.OverflowInt(lo) || value.OverflowInt(hi) {
+			return tagErrorf("invalid parameter %q: out of range for %s", rule, value.Type())
+		}
+		value.SetInt(min(max(value.Int(), lo), hi))
+	case value.CanUint():
+		lo, hi, err := clampRange(rule, cast.ToUint64E)
+		if err != nil {
+			return err
+		}
+		if value.OverflowUint(lo) || value.OverflowUint(hi) {
+			return tagErrorf("invalid parameter %q: out of range for %s", rule, value.Type())
+		}
+		value.SetUint(min(max(value.Uint(), lo), hi))
+	case value.CanFloat():
+		lo, hi, err := clampRange(rule, cast.ToFloat64E)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(min(max(value.Float(), lo), hi))
+	default:
+		return tagErrorf("%s does not support clamp", value.Kind())
+	}
+	return nil
+}
+
+// clampRange parses the "lo..hi" parameter of the clamp rule with conv.
+func clampRange[T cmp.Ordered](rule any, conv func(any) (T, error)) (lo, hi T, err error) {
+	loStr, hiStr, ok := strings.Cut(cast.ToString(rule), "..")
+	if !ok {
+		return lo, hi, tagErrorf("invalid parameter %q: clamp needs a range like 1..100", rule)
+	}
+	if lo, err = ruleParam(strings.TrimSpace(loStr), conv); err != nil {
+		return lo, hi, err
+	}
+	if hi, err = ruleParam(strings.TrimSpace(hiStr), conv); err != nil {
+		return lo, hi, err
+	}
+	if lo > hi {
+		return lo, hi, tagErrorf("invalid parameter %q: the lower bound is greater than the upper bound", rule)
+	}
+	return lo, hi, nil
+}
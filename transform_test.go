@@ -0,0 +1,127 @@
+package config_test
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nadim147c/go-config"
+)
+
+func TestTransforms(t *testing.T) {
+	_ = os.Setenv("TRANSFORM_REGION", "eu-west-1")
+	defer os.Unsetenv("TRANSFORM_REGION")
+
+	type App struct {
+		Name     string        `config:"name" check:"trim,title"`
+		Mode     string        `config:"mode" check:"trim,lower,enum='dev,prod'"`
+		Code     string        `config:"code" check:"upper,truncate=3"`
+		Bucket   string        `config:"bucket" check:"expandenv,lower"`
+		Workers  int           `config:"workers" check:"clamp=1..16"`
+		Ratio    float64       `config:"ratio" check:"clamp=0..1"`
+		Retries  uint8         `config:"retries" check:"clamp=1..5"`
+		Timeout  time.Duration `config:"timeout" check:"clamp=1s..1m"`
+		Tags     []string      `config:"tags" check:"truncate=2,dive,trim,lower"`
+		Greeting string        `config:"greeting" check:"truncate=5,min=5"`
+		Email    string        `config:"email" check:"trim,email"`
+	}
+
+	c := config.New()
+	c.Set("app.name", "  hello wORLD-o'neil ")
+	c.Set("app.mode", " PROD ")
+	c.Set("app.code", "abcdef")
+	c.Set("app.bucket", "Logs-${TRANSFORM_REGION}")
+	c.Set("app.workers", 64)
+	c.Set("app.ratio", -0.5)
+	c.Set("app.retries", 0)
+	c.Set("app.timeout", "10m")
+	c.Set("app.tags", []any{" A ", "B", "c"})
+	c.Set("app.greeting", "héllo, world")
+	c.Set("app.email", " ops@example.com ")
+
+	var app App
+	if err := c.Bind("app", &app); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	want := App{
+		Name:     "Hello WORLD-O'neil",
+		Mode:     "prod",
+		Code:     "ABC",
+		Bucket:   "logs-eu-west-1",
+		Workers:  16,
+		Ratio:    0,
+		Retries:  1,
+		Timeout:  time.Minute,
+		Tags:     []string{"a", "b"},
+		Greeting: "héllo",
+		Email:    "ops@example.com",
+	}
+	if !reflect.DeepEqual(app, want) {
+		t.Errorf("Bind():\nGot: %+v\nWant: %+v", app, want)
+	}
+}
+
+func TestTransformOrder(t *testing.T) {
+	type Order struct {
+		TrimFirst    string `check:"trim,truncate=3"`
+		TruncateLast string `check:"truncate=3,trim"`
+		After        string `check:"alpha,trim"`
+	}
+
+	tests := []struct {
+		field string
+		value string
+		want  string
+	}{
+		{"TrimFirst", "  abcdef", "abc"},
+		{"TruncateLast", "  abcdef", "a"},
+		{"After", " abc ", "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			sf, _ := reflect.TypeFor[Order]().FieldByName(tt.field)
+			value := reflect.New(sf.Type).Elem()
+			value.SetString(tt.value)
+			if err := config.Validate(sf, value, true); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if value.String() != tt.want {
+				t.Errorf("Validate() value = %q, want %q", value.String(), tt.want)
+			}
+		})
+	}
+
+	type Invalid struct {
+		Lower   int     `check:"lower"`
+		Range   int     `check:"clamp=10"`
+		Reverse int     `check:"clamp=10..1"`
+		Small   int8    `check:"clamp=1..1000"`
+		Clamp   string  `check:"clamp=1..2"`
+		Length  []int   `check:"truncate=x"`
+		Map     float64 `check:"truncate=1"`
+	}
+	err := config.ValidateTags(reflect.TypeFor[Invalid]())
+	for field := range strings.SplitSeq("Lower Range Reverse Small Clamp Length Map", " ") {
+		if err == nil || !strings.Contains(err.Error(), "on "+field+":") {
+			t.Errorf("ValidateTags() error = %v, want %s", err, field)
+		}
+	}
+}
+
+func TestDefaultBeforeRules(t *testing.T) {
+	type App struct {
+		Workers int    `config:"workers" check:"min=1,default=5"`
+		Mode    string `config:"mode" check:"enum='dev,prod',default=dev"`
+	}
+
+	var app App
+	if err := config.New().Bind("app", &app); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if want := (App{Workers: 5, Mode: "dev"}); app != want {
+		t.Errorf("Bind() = %+v, want %+v", app, want)
+	}
+}
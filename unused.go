@@ -0,0 +1,329 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// UnusedKey is a key found in the config files or the environment that
+// doesn't map to any field of the struct passed to UnusedKeys.
+type UnusedKey struct {
+	// Key is the unused key, such as "databse.host".
+	Key string
+	// Origin is where the key was set.
+	Origin Provenance
+	// Suggestion is the closest key that maps to a field, such as
+	// "database.host", or empty if no key is close enough.
+	Suggestion string
+}
+
+// String returns the key with its suggestion, such as
+// "databse.host → database.host".
+func (u UnusedKey) String() string {
+	if u.Suggestion == "" {
+		return u.Key
+	}
+	return u.Key + " → " + u.Suggestion
+}
+
+// UnusedKeysError is returned by BindStrict when some keys don't map to any
+// field.
+type UnusedKeysError struct {
+	Keys []UnusedKey
+}
+
+func (ue UnusedKeysError) Error() string {
+	keys := make([]string, 0, len(ue.Keys))
+	for u := range slices.Values(ue.Keys) {
+		if u.Suggestion == "" {
+			keys = append(keys, fmt.Sprintf("%s (%s)", u.Key, u.Origin))
+		} else {
+			keys = append(keys, fmt.Sprintf("%s (%s, did you mean %s?)", u.Key, u.Origin, u.Suggestion))
+		}
+	}
+	return "unknown keys: " + strings.Join(keys, ", ")
+}
+
+// BindStrict is like Bind but fails with an UnusedKeysError if the config files
+// or the environment have keys below the prefix that don't map to any field.
+// The fields are bound either way.
+func (c *Config) BindStrict(prefix string, v any) error {
+	if err := c.Bind(prefix, v); err != nil {
+		return err
+	}
+	if unused := c.UnusedKeys(v, prefix); len(unused) != 0 {
+		return UnusedKeysError{unused}
+	}
+	return nil
+}
+
+// UnusedKeys returns the keys below the prefix that are set in config files,
+// with Set or in environment variables with the prefix of SetEnvPrefix, but
+// that Bind(prefix, v) wouldn't read into any field. Each key comes with the
+// closest key that maps to a field, if any, so typos are easy to spot:
+//
+//	databse.host → database.host
+//
+// v is a struct, a pointer to one or a reflect.Type. Keys below map, interface
+// and TextUnmarshaler fields are always used. Environment variables are
+// matched by their EnvKey, so APP_DATABASE__MAXCONNS is used by a field
+// tagged "maxConns".
+func (c *Config) UnusedKeys(v any, prefix string) []UnusedKey {
+	rt, ok := v.(reflect.Type)
+	if !ok {
+		rt = reflect.TypeOf(v)
+	}
+	root := newKeyTree(rt, map[reflect.Type]bool{})
+
+	var rootParts []KeyPart
+	if prefix = strings.Trim(prefix, "."); prefix != "" {
+		parsed, err := KeySplit(prefix)
+		if err != nil {
+			return nil
+		}
+		rootParts = parsed.Parts
+	}
+
+	s := c.load()
+	found := map[string]UnusedKey{}
+	for e := range slices.Values(s.providers) {
+		var same func(a, b string) bool
+		switch e.layer {
+		case LayerFile:
+			same = func(a, b string) bool { return a == b }
+		case LayerEnv:
+			same = func(a, b string) bool { return sanitizeEnvKeyPart(a) == sanitizeEnvKeyPart(b) }
+		default:
+			continue
+		}
+		lister, ok := e.provider.(KeyLister)
+		if !ok {
+			continue
+		}
+
+		for key := range slices.Values(lister.Keys()) {
+			if !hasPrefixParts(key.Parts, rootParts, same) {
+				continue
+			}
+			rest := key.Parts[len(rootParts):]
+			if root.uses(rest, same) {
+				continue
+			}
+
+			name := formatKey(key.Parts)
+			if _, ok := found[name]; ok {
+				continue
+			}
+			u := UnusedKey{Key: name}
+			if origin, err := c.Origin(name); err == nil {
+				u.Origin = origin
+			}
+			if suggestion := root.suggest(rest, same); suggestion != nil {
+				u.Suggestion = formatKey(slices.Concat(rootParts, suggestion))
+			}
+			found[name] = u
+		}
+	}
+
+	out := make([]UnusedKey, 0, len(found))
+	for name := range slices.Values(slices.Sorted(maps.Keys(found))) {
+		out = append(out, found[name])
+	}
+	return out
+}
+
+// hasPrefixParts reports whether parts starts with prefix.
+func hasPrefixParts(parts, prefix []KeyPart, same func(a, b string) bool) bool {
+	if len(parts) < len(prefix) {
+		return false
+	}
+	for i, part := range prefix {
+		if !same(parts[i].String(), part.String()) {
+			return false
+		}
+	}
+	return true
+}
+
+// keyTree describes the keys Bind reads for a type.
+type keyTree struct {
+	// all is set for types that use every key below them.
+	all bool
+	// fields maps the keys of struct fields to their trees.
+	fields map[string]*keyTree
+	// elem is the tree of the elements of slices, arrays and maps with
+	// struct elements.
+	elem *keyTree
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// newKeyTree returns the keyTree of rt. seen holds the struct types being
+// visited, so recursive types end in a tree using every key.
+func newKeyTree(rt reflect.Type, seen map[reflect.Type]bool) *keyTree {
+	for rt != nil && rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+	if rt == nil || isTextType(rt) {
+		return &keyTree{all: true}
+	}
+
+	switch rt.Kind() {
+	case reflect.Struct:
+		if rt == reflect.TypeFor[time.Time]() || seen[rt] {
+			return &keyTree{all: true}
+		}
+		seen[rt] = true
+		defer delete(seen, rt)
+
+		tree := &keyTree{fields: map[string]*keyTree{}}
+		tree.addFields(rt, seen)
+		return tree
+	case reflect.Slice, reflect.Array, reflect.Map:
+		elem := newKeyTree(rt.Elem(), seen)
+		if elem.all {
+			return elem
+		}
+		return &keyTree{elem: elem}
+	default:
+		return &keyTree{all: true}
+	}
+}
+
+// addFields adds the fields of the struct type rt to t the way bindStruct
+// reads them: fields of embedded structs are added to t itself.
+func (t *keyTree) addFields(rt reflect.Type, seen map[reflect.Type]bool) {
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+		tag := strings.TrimSpace(sf.Tag.Get("config"))
+		if sf.PkgPath != "" || tag == "-" {
+			continue
+		}
+
+		if sf.Anonymous {
+			embedded := newKeyTree(sf.Type, seen)
+			if embedded.fields == nil {
+				t.all = t.all || embedded.all
+				continue
+			}
+			maps.Copy(t.fields, embedded.fields)
+			continue
+		}
+
+		key := tag
+		if key == "" {
+			key = sf.Name
+		}
+		parsed, err := KeySplit(strings.Trim(key, "."))
+		if err != nil || parsed.Len() == 0 {
+			continue
+		}
+
+		// Keys with dots, like "tls.cert", read nested values.
+		node := t
+		for part := range slices.Values(parsed.Parts[:parsed.LastIndex()]) {
+			next, ok := node.fields[part.String()]
+			if !ok || next.fields == nil {
+				next = &keyTree{fields: map[string]*keyTree{}}
+				node.fields[part.String()] = next
+			}
+			node = next
+		}
+		node.fields[parsed.Parts[parsed.LastIndex()].String()] = newKeyTree(sf.Type, seen)
+	}
+}
+
+// uses reports whether Bind reads the key with the given parts.
+func (t *keyTree) uses(parts []KeyPart, same func(a, b string) bool) bool {
+	if len(parts) == 0 || t.all {
+		return true
+	}
+	if t.elem != nil {
+		return t.elem.uses(parts[1:], same)
+	}
+	for name, field := range t.fields {
+		if same(name, parts[0].String()) {
+			return field.uses(parts[1:], same)
+		}
+	}
+	return false
+}
+
+// suggest returns the parts of the closest key Bind reads, or nil if no key is
+// close enough. Each part that names no field is replaced with the closest
+// field name.
+func (t *keyTree) suggest(parts []KeyPart, same func(a, b string) bool) []KeyPart {
+	if len(parts) == 0 || t.all {
+		return parts
+	}
+	if t.elem != nil {
+		rest := t.elem.suggest(parts[1:], same)
+		if rest == nil {
+			return nil
+		}
+		return append([]KeyPart{parts[0]}, rest...)
+	}
+
+	part := parts[0].String()
+	best, bestDist := "", -1
+	for name := range slices.Values(slices.Sorted(maps.Keys(t.fields))) {
+		if same(name, part) {
+			best, bestDist = name, 0
+			break
+		}
+		d := editDistance(strings.ToLower(name), strings.ToLower(part))
+		if d <= maxSuggestDistance(part) && (bestDist < 0 || d < bestDist) {
+			best, bestDist = name, d
+		}
+	}
+	if bestDist < 0 {
+		return nil
+	}
+
+	rest := t.fields[best].suggest(parts[1:], same)
+	if rest == nil {
+		return nil
+	}
+	return append([]KeyPart{{StringKey, best}}, rest...)
+}
+
+// maxSuggestDistance returns how many edits a key part may be away from a
+// field name to be suggested.
+func maxSuggestDistance(part string) int {
+	return max(1, min(3, len(part)/3))
+}
+
+// editDistance returns the optimal string alignment distance between a and b:
+// the number of insertions, deletions, substitutions and transpositions of
+// adjacent characters needed to turn a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// d[i][j] is the distance between ra[:i] and rb[:j].
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
@@ -0,0 +1,95 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+type unusedDatabase struct {
+	Host     string `config:"host"`
+	Port     int    `config:"port"`
+	MaxConns int    `config:"maxConns"`
+}
+
+type unusedApp struct {
+	Name     string         `config:"name"`
+	Database unusedDatabase `config:"database"`
+	Servers  []struct {
+		Host string `config:"host"`
+	} `config:"servers"`
+	Labels   map[string]string `config:"labels"`
+	TLSCert  string            `config:"tls.cert"`
+	Internal string            `config:"-"`
+}
+
+func TestUnusedKeys(t *testing.T) {
+	_ = os.Setenv("UNUSED_APP__DATABASE__MAXCONNS", "10")
+	_ = os.Setenv("UNUSED_APP__DATABASE__PROT", "5432")
+	defer os.Unsetenv("UNUSED_APP__DATABASE__MAXCONNS")
+	defer os.Unsetenv("UNUSED_APP__DATABASE__PROT")
+
+	c := config.New()
+	c.SetEnvPrefix("UNUSED")
+	c.Set("app.name", "MyApp")
+	c.Set("app.databse.host", "localhost")
+	c.Set("app.servers[0].host", "a.example.com")
+	c.Set("app.servers[1].hots", "b.example.com")
+	c.Set("app.labels.team", "core")
+	c.Set("app.tls.cert", "/etc/cert.pem")
+	c.Set("app.tls.key", "/etc/key.pem")
+	c.Set("app.Internal", "secret")
+	c.Set("app.completely_unrelated", true)
+	c.Set("other.key", 1)
+	c.SetDefault("app.unknown_default", 1)
+
+	got := map[string]string{}
+	for _, u := range c.UnusedKeys(&unusedApp{}, "app") {
+		got[u.Key] = u.Suggestion
+	}
+
+	want := map[string]string{
+		"app.Internal":             "",
+		"app.completely_unrelated": "",
+		"app.database.prot":        "app.database.port",
+		"app.databse.host":         "app.database.host",
+		"app.servers.1.hots":       "app.servers.1.host",
+		"app.tls.key":              "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnusedKeys():\nGot: %v\nWant: %v", got, want)
+	}
+}
+
+func TestBindStrict(t *testing.T) {
+	c := config.New()
+	c.Set("app.name", "MyApp")
+	c.Set("app.databse.host", "localhost")
+
+	var app unusedApp
+	err := c.BindStrict("app", &app)
+
+	var ue config.UnusedKeysError
+	if !errors.As(err, &ue) {
+		t.Fatalf("BindStrict() error = %v, want UnusedKeysError", err)
+	}
+	if len(ue.Keys) != 1 || ue.Keys[0].String() != "app.databse.host → app.database.host" {
+		t.Errorf("UnusedKeysError.Keys = %v", ue.Keys)
+	}
+	if ue.Keys[0].Origin.Source != "(set in code)" {
+		t.Errorf("Origin = %v, want a value set in code", ue.Keys[0].Origin)
+	}
+	if app.Name != "MyApp" {
+		t.Errorf("Name = %q, want fields bound despite unused keys", app.Name)
+	}
+
+	c = config.New()
+	c.Set("app.name", "MyApp")
+	c.Set("app.database.host", "localhost")
+	if err := c.BindStrict("app", &app); err != nil {
+		t.Errorf("BindStrict() error = %v", err)
+	}
+}
@@ -21,7 +21,8 @@ import (
 //   - required: Field must be changed from its zero value (checked via the
 //     `changed` flag).
 //   - default: If the field is zero-valued, sets it to the specified default
-//     value (supports string, int, uint, float, bool).
+//     value (supports string, int, uint, float, bool). Like the transforms, it
+//     runs before the other rules.
 //   - enum: Ensures the field is a one of the given comma (,) sperated enum.
 //     Note: enum must be inside a qoute. enum='a,b,c'
 //   - base64: Ensures the field is a valid base64-encoded string (length,
@@ -36,6 +37,49 @@ import (
 //     length; for integers/unsigned integers, enforces a minimum numeric value.
 //   - max: For strings, arrays, slices, channels, and maps, enforces a maximum
 //     length; for integers/unsigned integers, enforces a maximum numeric value.
+//     Bounds of time.Duration fields are durations, such as min=1s,max=1h.
+//   - url: Field must be an absolute URL. url='http,https' limits the scheme.
+//   - hostname: Field must be an RFC 1123 hostname.
+//   - ip, ipv4, ipv6: Field must be an IP address, of the given version.
+//   - cidr: Field must be an IP prefix in CIDR notation, such as 10.0.0.0/8.
+//   - port: Field must be a port number between 1 and 65535.
+//   - hostport: Field must be a host and a port, such as example.com:443 or
+//     :8080. The host is a hostname or an IP address.
+//   - mac: Field must be a MAC address. Like the rules above, it works on
+//     strings and on the types holding parsed values: net.IP and netip.Addr,
+//     netip.Prefix, url.URL, net.HardwareAddr and integers for port.
+//   - trim, lower, upper, title, expandenv: Transform a string by trimming
+//     spaces, changing its case or expanding environment variables.
+//   - truncate: Transforms a string to at most N characters, or a slice to at
+//     most N elements: truncate=64.
+//   - clamp: Transforms a number or duration to be within a range, such as
+//     clamp=1..100 or clamp=1s..1m.
+//   - expand: Transforms a path with FindPath, expanding ~ and variables like
+//     $XDG_CONFIG_HOME and making it absolute, before the other rules run.
+//     Relative paths set in config files resolve against the directory of the
+//     file, like includes; other relative paths against the working directory.
+//   - abs: Field must be an absolute path.
+//   - exists, file, dir: Path must exist, as a regular file or a directory.
+//   - readable, writable, executable: Path must exist and allow the access.
+//     Without expand, relative paths are checked against the working
+//     directory.
+//   - after, before: time.Time field must be after or before the given date or
+//     time, such as after=2020-01-01, or the current time with before=now.
+//   - dive: The rules after dive apply to each element of a slice, array or
+//     map instead of the field itself, so `check:"min=1,dive,email"` needs at
+//     least one element and validates each as an email. On maps, the rules
+//     between "keys" and "endkeys" right after dive apply to the keys:
+//     `check:"dive,keys,alpha,endkeys,min=1"`. Errors name the element, such
+//     as "hosts.2".
+//
+// Cross-field rules, such as required_if and gtfield, compare the field with
+// other fields of its struct. Bind applies them once the whole struct is bound;
+// Validate, which only sees the field, skips them. See crossFieldRules.
+//
+// Transforms run first, in the order of the tag, so the other rules check the
+// transformed value: `check:"trim,lower,enum='a,b'"`.
+//
+// Rules registered with RegisterRule can be used alongside these.
 //
 // Parameters:
 //   - sf: The struct field metadata.
@@ -44,270 +88,594 @@ import (
 //     original state.
 //
 // Returns:
-//   - error: A descriptive error if validation fails, or nil if all rules pass.
-//
-// Panics if:
-//   - An unknown validation rule is provided.
-//   - A rule is applied to an unsupported type.
-//   - "default" rule is applied to an unsupported kind.
-//   - Type mismatches occur for rules like base64, email, uuid, alpha,
-//     alphanumeric, number, or match.
+//   - error: A ValidationError if validation fails, or nil if all rules pass.
+//     Problems with the tag itself, such as an unknown rule, a rule applied to
+//     an unsupported type or an invalid parameter, are returned as TagError.
+//     Use ValidateTags to find them before binding.
 func Validate(sf reflect.StructField, sfv reflect.Value, changed bool) error {
+	return validateField(RuleContext{Field: sf, Value: sfv, Changed: changed}, false)
+}
+
+// validateField applies the rules of the "check" tag of ctx.Field. If cross is
+// set, only the cross-field rules are applied; otherwise they are skipped, so
+// Bind can apply them once the whole struct is bound. Problems with the tag
+// itself are only reported when cross is unset.
+func validateField(ctx RuleContext, cross bool) error {
+	sf := ctx.Field
 	ruleTag, ok := sf.Tag.Lookup("check")
 	if !ok {
 		return nil
 	}
 
-	rules := Must(parseValidateTag(ruleTag))
-	exclusive(rules, "required", "default")
+	head, dive, err := splitDive(ruleTag)
+	if err != nil {
+		if cross {
+			return nil
+		}
+		return TagError{Field: sf.Name, Tag: ruleTag, Err: err}
+	}
+	rules, order, err := parseValidateTag(head)
+	if err != nil {
+		if cross {
+			return nil
+		}
+		return TagError{Field: sf.Name, Tag: ruleTag, Err: err}
+	}
+	if err := exclusive(rules, "required", "default"); err != nil && !cross {
+		return TagError{Field: sf.Name, Tag: ruleTag, Err: err}
+	}
 
-	for name, rule := range rules {
-		switch name {
-		default:
-			panic(fmt.Sprintf("unknown validation rule %q", name))
-		case "required":
-			if !changed {
-				return errors.New("value is not changed")
+	// Transforms run first, in the order of the tag, so the other rules check
+	// the canonical value
+	for transform := range slices.Values([]bool{true, false}) {
+		for name := range slices.Values(order) {
+			rule := rules[name]
+			if crossFieldRules[name] != cross || transformRules[name] != transform {
+				continue
 			}
-		case "default":
-			value := resolvePointer(sfv)
-			if !changed {
-				switch value.Kind() {
-				case reflect.String:
-					sfv.SetString(Must(cast.ToStringE(rule)))
-				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-					if value.Type() == reflect.TypeOf(time.Duration(0)) {
-						sfv.SetInt(int64(Must(cast.ToDurationE(rule))))
-						continue
-					}
-					sfv.SetInt(Must(cast.ToInt64E(rule)))
-				case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-					sfv.SetUint(Must(cast.ToUint64E(rule)))
-				case reflect.Float32, reflect.Float64:
-					sfv.SetFloat(Must(cast.ToFloat64E(rule)))
-				case reflect.Bool:
-					sfv.SetBool(Must(cast.ToBoolE(rule)))
-				default:
-					panic(fmt.Sprintf("%s does not support default value assignment", value.Kind()))
-				}
+			ctx.Param = ""
+			if param, ok := rule.(string); ok {
+				ctx.Param = param
 			}
-		case "enum":
-			value := resolvePointer(sfv)
-			choices := strings.Split(Must(cast.ToStringE(rule)), ",")
-			switch value.Kind() {
-			case reflect.String:
-				str := value.String()
-				if !slices.Contains(choices, str) {
-					return fmt.Errorf("invalid enum value %q, must be one of %v", str, choices)
+			if err := validateRule(ctx, name, rule); err != nil {
+				var te TagError
+				if errors.As(err, &te) {
+					te.Field, te.Tag, te.Rule = sf.Name, ruleTag, name
+					return ruleError{name, te}
 				}
+				return ctx.validationError(name, err)
+			}
+		}
+	}
 
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				val := value.Int()
-				choices := Must(cast.ToInt64SliceE(choices))
-				if !slices.Contains(choices, val) {
-					return fmt.Errorf("invalid enum value %d, must be one of %v", val, choices)
-				}
+	if dive != nil {
+		return validateDive(ctx, dive, cross)
+	}
+	return nil
+}
 
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				val := value.Uint()
-				choices := Must(cast.ToUint64SliceE(choices))
-				if !slices.Contains(choices, val) {
-					return fmt.Errorf("invalid enum value %d, must be one of %v", val, choices)
-				}
+// validationError returns err, the failure of the rule with the given name, as
+// a ValidationError. Errors that already are ValidationErrors, like those of
+// elements, are returned as they are.
+func (ctx RuleContext) validationError(name string, err error) error {
+	var ve ValidationError
+	if errors.As(err, &ve) {
+		return err
+	}
 
-			case reflect.Float32, reflect.Float64:
-				val := value.Float()
-				choices := Must(cast.ToFloat64SliceE(choices))
-				if !slices.Contains(choices, val) {
-					return fmt.Errorf("invalid enum value %f, must be one of %v", val, choices)
-				}
+	ve = ValidationError{Key: ctx.Key, Field: ctx.Field.Name, Rule: name, Param: ctx.Param, Err: err}
+	if ve.Key == "" {
+		ve.Key = ctx.Field.Name
+	}
+	if value := resolvePointer(ctx.Value); value.IsValid() && value.CanInterface() {
+		ve.Value = value.Interface()
+	}
+	return ve
+}
 
-			default:
-				panic(fmt.Sprintf("%s does not support enum validation", value.Kind()))
-			}
-		case "base64":
-			value := resolvePointer(sfv)
-			if value.Kind() != reflect.String {
-				panic("base64 must be a string")
+// TagError indicates a "check" tag is invalid: it can't be parsed, names an
+// unknown rule, applies a rule to an unsupported type or has an invalid
+// parameter.
+type TagError struct {
+	// Field is the path of the struct field, such as "Server.TLS.Cert".
+	Field string
+	// Tag is the "check" tag of the field.
+	Tag string
+	// Rule is the invalid rule, or empty if the tag couldn't be parsed.
+	Rule string
+	// Err is the cause.
+	Err error
+}
+
+func (te TagError) Error() string {
+	if te.Rule == "" {
+		return fmt.Sprintf("invalid check tag %q on %s: %v", te.Tag, te.Field, te.Err)
+	}
+	return fmt.Sprintf("invalid check tag %q on %s: %s: %v", te.Tag, te.Field, te.Rule, te.Err)
+}
+
+func (te TagError) Unwrap() error {
+	return te.Err
+}
+
+// tagErrorf returns a TagError with a formatted cause. Validate fills in the
+// field, the tag and the rule.
+func tagErrorf(format string, args ...any) error {
+	return TagError{Err: fmt.Errorf(format, args...)}
+}
+
+// ValidateTags reports every invalid "check" tag of the struct type t and of
+// the structs nested in it, joined with errors.Join. Each problem is a
+// TagError. Call it at startup to catch tag typos before Bind runs into them.
+//
+// Example:
+//
+//	if err := config.ValidateTags(reflect.TypeFor[AppConfig]()); err != nil {
+//		log.Fatal(err)
+//	}
+func ValidateTags(t reflect.Type) error {
+	var errs []error
+	validateTags(t, "", map[reflect.Type]bool{}, &errs)
+	return errors.Join(errs...)
+}
+
+// validateTags appends the TagErrors of the struct type t to errs. path is the
+// path of the field holding t.
+func validateTags(t reflect.Type, path string, seen map[reflect.Type]bool, errs *[]error) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice ||
+		t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	parent := reflect.New(t).Elem()
+	for i := range t.NumField() {
+		if t.Field(i).PkgPath == "" {
+			parent.Field(i).Set(newValue(t.Field(i).Type))
+		}
+	}
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if sf.PkgPath != "" || strings.TrimSpace(sf.Tag.Get("config")) == "-" {
+			continue
+		}
+
+		field := sf.Name
+		if path != "" {
+			field = path + "." + sf.Name
+		}
+
+		if _, ok := sf.Tag.Lookup("check"); ok {
+			// Rules run on a zero value; failures of the value itself are
+			// expected and only the problems of the tag are kept.
+			ctx := RuleContext{Field: sf, Value: parent.Field(i), Parent: parent, Changed: true}
+			for cross := range slices.Values([]bool{false, true}) {
+				err := validateField(ctx, cross)
+				if te := (TagError{}); errors.As(err, &te) {
+					te.Field = field
+					*errs = append(*errs, te)
+				}
 			}
-			str := value.String()
+		}
+
+		if sf.Anonymous {
+			validateTags(sf.Type, path, seen, errs)
+		} else {
+			validateTags(sf.Type, field, seen, errs)
+		}
+	}
+}
 
-			// Quick base64 structural validation
-			if len(str)%4 != 0 {
-				return errors.New("invalid base64 length")
+// newValue returns a settable zero value of t, with pointers allocated.
+func newValue(t reflect.Type) reflect.Value {
+	v := reflect.New(t).Elem()
+	for cur := v; cur.Kind() == reflect.Pointer; cur = cur.Elem() {
+		cur.Set(reflect.New(cur.Type().Elem()))
+	}
+	return v
+}
+
+// ruleError is an error of a validation rule.
+type ruleError struct {
+	rule string
+	err  error
+}
+
+func (re ruleError) Error() string {
+	return re.err.Error()
+}
+
+func (re ruleError) Unwrap() error {
+	return re.err
+}
+
+// ruleParam converts the parameter of a rule, returning a TagError if it is
+// invalid.
+func ruleParam[T any](rule any, conv func(any) (T, error)) (T, error) {
+	v, err := conv(rule)
+	if err != nil {
+		return v, tagErrorf("invalid parameter %q: %v", rule, err)
+	}
+	return v, nil
+}
+
+// stringValue returns the value of a field the rule only supports for
+// strings. The value is invalid for a nil pointer, which the rules skip.
+func stringValue(name string, sfv reflect.Value) (reflect.Value, error) {
+	if elemType(sfv.Type()).Kind() != reflect.String {
+		return reflect.Value{}, tagErrorf("%s must be a string", name)
+	}
+	return resolvePointer(sfv), nil
+}
+
+// elemType returns t with pointers removed.
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// validateRule applies a single rule of a "check" tag to the field.
+func validateRule(ctx RuleContext, name string, rule any) error {
+	sfv, changed := ctx.Value, ctx.Changed
+	switch name {
+	default:
+		if fn, ok := lookupRule(name); ok {
+			return fn(ctx)
+		}
+		return tagErrorf("unknown validation rule %q", name)
+	case "required_if", "required_unless", "required_with", "required_without",
+		"excluded_with", "excluded_without":
+		return validateRequiredIf(ctx, name)
+	case "eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield":
+		return validateFieldComparison(ctx, name)
+	case "required":
+		if !changed {
+			return errors.New("value is required")
+		}
+	case "default":
+		value := resolvePointer(sfv)
+		var def reflect.Value
+		switch value.Kind() {
+		case reflect.String:
+			v, err := ruleParam(rule, cast.ToStringE)
+			if err != nil {
+				return err
 			}
-			for i := 0; i < len(str); i++ {
-				c := str[i]
-				if !(c >= 'A' && c <= 'Z' ||
-					c >= 'a' && c <= 'z' ||
-					c >= '0' && c <= '9' ||
-					c == '+' || c == '/' || c == '=') {
-					return fmt.Errorf("invalid base64 character at position %d", i)
+			def = reflect.ValueOf(v)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if value.Type() == reflect.TypeOf(time.Duration(0)) {
+				v, err := ruleParam(rule, cast.ToDurationE)
+				if err != nil {
+					return err
 				}
+				def = reflect.ValueOf(v)
+				break
 			}
-			// Padding check
-			if pad := strings.Count(str, "="); pad > 2 ||
-				(pad > 0 && !strings.HasSuffix(str, strings.Repeat("=", pad))) {
-				return errors.New("invalid base64 padding")
-			}
-		case "email":
-			value := resolvePointer(sfv)
-			if value.Kind() != reflect.String {
-				panic("email must be a string")
+			v, err := ruleParam(rule, cast.ToInt64E)
+			if err != nil {
+				return err
 			}
-			str := value.String()
-			addr, err := mail.ParseAddress(str)
+			def = reflect.ValueOf(v)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v, err := ruleParam(rule, cast.ToUint64E)
 			if err != nil {
-				return fmt.Errorf("invalid email: %w", err)
+				return err
 			}
-			if addr.Name != "" {
-				return errors.New("email must not contain a display name")
+			def = reflect.ValueOf(v)
+		case reflect.Float32, reflect.Float64:
+			v, err := ruleParam(rule, cast.ToFloat64E)
+			if err != nil {
+				return err
 			}
-			sfv.SetString(addr.Address)
-		case "uuid":
-			value := resolvePointer(sfv)
-			if value.Kind() != reflect.String {
-				panic("uuid must be a string")
+			def = reflect.ValueOf(v)
+		case reflect.Bool:
+			v, err := ruleParam(rule, cast.ToBoolE)
+			if err != nil {
+				return err
 			}
+			def = reflect.ValueOf(v)
+		default:
+			return tagErrorf("%s does not support default value assignment", value.Kind())
+		}
+		if !changed {
+			value.Set(def.Convert(value.Type()))
+		}
+	case "enum":
+		value := resolvePointer(sfv)
+		choices := strings.Split(cast.ToString(rule), ",")
+		switch value.Kind() {
+		case reflect.String:
 			str := value.String()
-			_, err := uuid.Parse(str)
+			if !slices.Contains(choices, str) {
+				return fmt.Errorf("invalid enum value %q, must be one of %v", str, choices)
+			}
+
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			val := value.Int()
+			choices, err := ruleParam(choices, cast.ToInt64SliceE)
 			if err != nil {
-				return fmt.Errorf("%q is not valid uuid", str)
+				return err
 			}
-		case "alpha":
-			value := resolvePointer(sfv)
-			if value.Kind() != reflect.String {
-				panic("alpha must be a string")
-			}
-			str := value.String()
-			for i := 0; i < len(str); i++ {
-				c := str[i]
-				if !(c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z') {
-					return fmt.Errorf("alpha must contain only letters, found '%c' at position %d", c, i)
-				}
+			if !slices.Contains(choices, val) {
+				return fmt.Errorf("invalid enum value %d, must be one of %v", val, choices)
 			}
 
-		case "alphanumeric":
-			value := resolvePointer(sfv)
-			if value.Kind() != reflect.String {
-				panic("alphanumeric must be a string")
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			val := value.Uint()
+			choices, err := ruleParam(choices, cast.ToUint64SliceE)
+			if err != nil {
+				return err
 			}
-			str := value.String()
-			for i := 0; i < len(str); i++ {
-				c := str[i]
-				if !(c >= 'A' && c <= 'Z' ||
-					c >= 'a' && c <= 'z' ||
-					c >= '0' && c <= '9') {
-					return fmt.Errorf("alphanumeric must contain only letters or digits, found '%c' at position %d", c, i)
-				}
+			if !slices.Contains(choices, val) {
+				return fmt.Errorf("invalid enum value %d, must be one of %v", val, choices)
 			}
 
-		case "number":
-			value := resolvePointer(sfv)
-			if value.Kind() != reflect.String {
-				panic("number must be a string")
+		case reflect.Float32, reflect.Float64:
+			val := value.Float()
+			choices, err := ruleParam(choices, cast.ToFloat64SliceE)
+			if err != nil {
+				return err
 			}
-			str := value.String()
-			if len(str) == 0 {
-				return errors.New("number must not be empty")
+			if !slices.Contains(choices, val) {
+				return fmt.Errorf("invalid enum value %f, must be one of %v", val, choices)
 			}
-			for i := 0; i < len(str); i++ {
-				if str[i] < '0' || str[i] > '9' {
-					return fmt.Errorf("number must contain only digits, found '%c' at position %d", str[i], i)
-				}
+
+		default:
+			return tagErrorf("%s does not support enum validation", value.Kind())
+		}
+	case "base64":
+		value, err := stringValue(name, sfv)
+		if err != nil || !value.IsValid() {
+			return err
+		}
+		str := value.String()
+
+		// Quick base64 structural validation
+		if len(str)%4 != 0 {
+			return errors.New("invalid base64 length")
+		}
+		for i := 0; i < len(str); i++ {
+			c := str[i]
+			if !(c >= 'A' && c <= 'Z' ||
+				c >= 'a' && c <= 'z' ||
+				c >= '0' && c <= '9' ||
+				c == '+' || c == '/' || c == '=') {
+				return fmt.Errorf("invalid base64 character at position %d", i)
 			}
-		case "match":
-			re := regexp.MustCompile(Must(cast.ToStringE(rule)))
-			value := resolvePointer(sfv)
-			if value.Kind() != reflect.String {
-				panic("match pattern must be a string")
+		}
+		// Padding check
+		if pad := strings.Count(str, "="); pad > 2 ||
+			(pad > 0 && !strings.HasSuffix(str, strings.Repeat("=", pad))) {
+			return errors.New("invalid base64 padding")
+		}
+	case "email":
+		value, err := stringValue(name, sfv)
+		if err != nil || !value.IsValid() {
+			return err
+		}
+		str := value.String()
+		addr, err := mail.ParseAddress(str)
+		if err != nil {
+			return fmt.Errorf("invalid email: %w", err)
+		}
+		if addr.Name != "" {
+			return errors.New("email must not contain a display name")
+		}
+		value.SetString(addr.Address)
+	case "uuid":
+		value, err := stringValue(name, sfv)
+		if err != nil || !value.IsValid() {
+			return err
+		}
+		str := value.String()
+		if _, err := uuid.Parse(str); err != nil {
+			return fmt.Errorf("%q is not a valid UUID", str)
+		}
+	case "alpha":
+		value, err := stringValue(name, sfv)
+		if err != nil || !value.IsValid() {
+			return err
+		}
+		str := value.String()
+		for i := 0; i < len(str); i++ {
+			c := str[i]
+			if !(c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z') {
+				return fmt.Errorf("alpha must contain only letters, found '%c' at position %d", c, i)
 			}
-			if !re.MatchString(value.String()) {
-				return fmt.Errorf("string must match following the pattern: %s", rule)
+		}
+
+	case "alphanumeric":
+		value, err := stringValue(name, sfv)
+		if err != nil || !value.IsValid() {
+			return err
+		}
+		str := value.String()
+		for i := 0; i < len(str); i++ {
+			c := str[i]
+			if !(c >= 'A' && c <= 'Z' ||
+				c >= 'a' && c <= 'z' ||
+				c >= '0' && c <= '9') {
+				return fmt.Errorf("alphanumeric must contain only letters or digits, found '%c' at position %d", c, i)
 			}
-		case "min":
-			value := resolvePointer(sfv)
-			kind := sf.Type.Kind()
-			switch kind {
-			case reflect.String, reflect.Array, reflect.Slice, reflect.Chan, reflect.Map:
-				limit := Must(cast.ToIntE(rule))
-				if value.Len() < limit {
-					return fmt.Errorf("%s len (%d) is less the minimum len (%d)", kind, value.Len(), limit)
-				}
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				limit := Must(cast.ToInt64E(rule))
-				value := value.Int()
-				if value < limit {
-					return fmt.Errorf("%d is less the minimum (%d)", value, limit)
-				}
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				limit := Must(cast.ToUint64E(rule))
-				value := value.Uint()
-				if value < limit {
-					return fmt.Errorf("%d is less the minimum (%d)", value, limit)
-				}
-			case reflect.Float32, reflect.Float64:
-				limit := Must(cast.ToFloat64E(rule))
-				value := value.Float()
-				if value < limit {
-					return fmt.Errorf("%f is less the minimum (%f)", value, limit)
-				}
-			default:
-				panic(fmt.Sprintf("%s does not support min value", kind))
+		}
+
+	case "number":
+		value, err := stringValue(name, sfv)
+		if err != nil || !value.IsValid() {
+			return err
+		}
+		str := value.String()
+		if len(str) == 0 {
+			return errors.New("number must not be empty")
+		}
+		for i := 0; i < len(str); i++ {
+			if str[i] < '0' || str[i] > '9' {
+				return fmt.Errorf("number must contain only digits, found '%c' at position %d", str[i], i)
 			}
-		case "max":
-			value := resolvePointer(sfv)
-			kind := sf.Type.Kind()
-			switch kind {
-			case reflect.String, reflect.Array, reflect.Slice, reflect.Chan, reflect.Map:
-				limit := Must(cast.ToIntE(rule))
-				if value.Len() > limit {
-					return fmt.Errorf("%s len (%d) is less the maximum len (%d)", kind, value.Len(), limit)
-				}
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				limit := Must(cast.ToInt64E(rule))
-				value := value.Int()
-				if value > limit {
-					return fmt.Errorf("%d is less the maximum (%d)", value, limit)
-				}
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				limit := Must(cast.ToUint64E(rule))
-				value := value.Uint()
-				if value > limit {
-					return fmt.Errorf("%d is less the maximum (%d)", value, limit)
-				}
-			case reflect.Float32, reflect.Float64:
-				limit := Must(cast.ToFloat64E(rule))
-				value := value.Float()
-				if value > limit {
-					return fmt.Errorf("%f is less the maximum (%f)", value, limit)
-				}
-			default:
-				panic(fmt.Sprintf("%s does not support min value", kind))
+		}
+	case "match":
+		re, err := regexp.Compile(cast.ToString(rule))
+		if err != nil {
+			return tagErrorf("invalid pattern: %v", err)
+		}
+		value, err := stringValue(name, sfv)
+		if err != nil || !value.IsValid() {
+			return err
+		}
+		if !re.MatchString(value.String()) {
+			return fmt.Errorf("%q does not match the pattern %s", value.String(), rule)
+		}
+	case "min", "max":
+		return validateLimit(resolvePointer(sfv), name, rule)
+	case "after", "before":
+		return validateTimeBound(sfv, name, rule)
+	case "url", "hostname", "ip", "ipv4", "ipv6", "cidr", "port", "hostport", "mac":
+		return validateNetwork(sfv, name, rule)
+	case "trim", "lower", "upper", "title", "expandenv":
+		return transformString(sfv, name)
+	case "truncate":
+		return transformTruncate(sfv, rule)
+	case "clamp":
+		return transformClamp(sfv, rule)
+	case "expand", "abs", "exists", "file", "dir", "readable", "writable", "executable":
+		return validatePath(ctx, name)
+	}
+	return nil
+}
+
+// validateLimit applies the min or max rule. Strings, arrays, slices, channels
+// and maps are limited by length, numbers by value.
+func validateLimit(value reflect.Value, name string, rule any) error {
+	isMin := name == "min"
+	compare := func(less, greater bool) bool {
+		return isMin && less || !isMin && greater
+	}
+	limitName := "maximum"
+	relation := "greater than"
+	if isMin {
+		limitName = "minimum"
+		relation = "less than"
+	}
+
+	kind := value.Kind()
+	switch kind {
+	case reflect.String, reflect.Array, reflect.Slice, reflect.Chan, reflect.Map:
+		limit, err := ruleParam(rule, cast.ToIntE)
+		if err != nil {
+			return err
+		}
+		if n := value.Len(); compare(n < limit, n > limit) {
+			return fmt.Errorf("%s len (%d) is %s the %s len (%d)", kind, n, relation, limitName, limit)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value.Type() == reflect.TypeOf(time.Duration(0)) {
+			limit, err := ruleParam(rule, cast.ToDurationE)
+			if err != nil {
+				return err
 			}
+			if d := time.Duration(value.Int()); compare(d < limit, d > limit) {
+				return fmt.Errorf("%s is %s the %s (%s)", d, relation, limitName, limit)
+			}
+			break
+		}
+		limit, err := ruleParam(rule, cast.ToInt64E)
+		if err != nil {
+			return err
+		}
+		if n := value.Int(); compare(n < limit, n > limit) {
+			return fmt.Errorf("%d is %s the %s (%d)", n, relation, limitName, limit)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		limit, err := ruleParam(rule, cast.ToUint64E)
+		if err != nil {
+			return err
+		}
+		if n := value.Uint(); compare(n < limit, n > limit) {
+			return fmt.Errorf("%d is %s the %s (%d)", n, relation, limitName, limit)
+		}
+	case reflect.Float32, reflect.Float64:
+		limit, err := ruleParam(rule, cast.ToFloat64E)
+		if err != nil {
+			return err
+		}
+		if n := value.Float(); compare(n < limit, n > limit) {
+			return fmt.Errorf("%f is %s the %s (%f)", n, relation, limitName, limit)
+		}
+	default:
+		return tagErrorf("%s does not support %s value", kind, name)
+	}
+	return nil
+}
+
+// validateTimeBound applies the after or before rule to a time.Time. The
+// parameter is a date or time, such as "2020-01-01" or "2020-01-01T10:00:00Z",
+// or "now" for the time of the validation.
+func validateTimeBound(sfv reflect.Value, name string, rule any) error {
+	if t := elemType(sfv.Type()); t != reflect.TypeOf(time.Time{}) {
+		return tagErrorf("%s does not support %s value", t.Kind(), name)
+	}
+
+	var bound time.Time
+	if rule == "now" {
+		bound = time.Now()
+	} else {
+		var err error
+		bound, err = ruleParam(rule, cast.ToTimeE)
+		if err != nil {
+			return err
 		}
 	}
+
+	value := resolvePointer(sfv)
+	if !value.IsValid() {
+		return nil // nil pointer
+	}
+	t := value.Interface().(time.Time)
+	switch {
+	case name == "after" && !t.After(bound):
+		return fmt.Errorf("%s is not after %s", t.Format(time.RFC3339), bound.Format(time.RFC3339))
+	case name == "before" && !t.Before(bound):
+		return fmt.Errorf("%s is not before %s", t.Format(time.RFC3339), bound.Format(time.RFC3339))
+	}
 	return nil
 }
 
-func exclusive(rules map[string]any, a, b string) {
+// exclusive returns an error if both rules a and b are present.
+func exclusive(rules map[string]any, a, b string) error {
 	_, okA := rules[a]
 	_, okB := rules[b]
 	if okA && okB {
-		panic("%q and %q are mutually exclusive")
+		return fmt.Errorf("%q and %q are mutually exclusive", a, b)
 	}
+	return nil
 }
 
-func parseValidateTag(tag string) (map[string]any, error) {
+// parseValidateTag parses a "check" tag into its rules and returns the names of
+// the rules in the order of the tag.
+func parseValidateTag(tag string) (map[string]any, []string, error) {
 	out := map[string]any{}
+	var order []string
+	add := func(name string, rule any) {
+		if _, ok := out[name]; !ok {
+			order = append(order, name)
+		}
+		out[name] = rule
+	}
 	tag = strings.TrimSpace(tag)
 	if tag == "" {
-		return out, nil
+		return out, order, nil
 	}
 
 	parts, err := splitCSVRespectQuotes(tag)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	for _, p := range parts {
 		p = strings.TrimSpace(p)
@@ -316,12 +684,12 @@ func parseValidateTag(tag string) (map[string]any, error) {
 		}
 		if !strings.Contains(p, "=") {
 			// flags like "required"
-			out[p] = true
+			add(p, true)
 			continue
 		}
 		kv := strings.SplitN(p, "=", 2)
 		if len(kv) != 2 {
-			return nil, fmt.Errorf("malformed rule: %q", p)
+			return nil, nil, fmt.Errorf("malformed rule: %q", p)
 		}
 		k := strings.TrimSpace(kv[0])
 		v := strings.TrimSpace(kv[1])
@@ -330,11 +698,11 @@ func parseValidateTag(tag string) (map[string]any, error) {
 			v = v[1 : len(v)-1]
 		}
 		if k == "" {
-			return nil, fmt.Errorf("empty key in rule: %q", p)
+			return nil, nil, fmt.Errorf("empty key in rule: %q", p)
 		}
-		out[k] = v
+		add(k, v)
 	}
-	return out, nil
+	return out, order, nil
 }
 
 func splitCSVRespectQuotes(s string) ([]string, error) {
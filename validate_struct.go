@@ -0,0 +1,145 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// ValidateStruct applies the "check" tags of the struct v, and of the structs
+// nested in it through fields, pointers, slices, arrays and maps, the same way
+// Bind does: transforms, rules, cross-field rules, dive and Validate methods.
+// Use it for values built in code, decoded from an API or produced by tests.
+//
+// v is a struct or a pointer to one; pass a pointer to keep the changes of
+// transforms and defaults. Without a Config, a field counts as set when it is
+// not zero, which is what required checks. ValidateConfig methods aren't
+// called, and neither is the Validate method of v itself, so Validate methods
+// may call ValidateStruct.
+//
+// It returns BindErrors listing every failure, with keys built from the
+// "config" tags like Bind(""), such as "servers.0.port".
+func ValidateStruct(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return errors.New("input must not be nil")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("input must be a struct or a pointer to one, got %s", rv.Kind())
+	}
+	rv = settable(rv)
+
+	s := &structValidator{seen: map[uintptr]bool{}}
+	return s.validateFields(rv, "").err()
+}
+
+// structValidator walks values for ValidateStruct.
+type structValidator struct {
+	// seen holds the pointers being visited, so cyclic values end.
+	seen map[uintptr]bool
+}
+
+// validateValue validates the structs in rv, whose key is key.
+func (s *structValidator) validateValue(rv reflect.Value, key string) BindErrors {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		if rv.Kind() == reflect.Pointer {
+			if s.seen[rv.Pointer()] {
+				return nil
+			}
+			s.seen[rv.Pointer()] = true
+			defer delete(s.seen, rv.Pointer())
+		}
+		rv = rv.Elem()
+	}
+
+	var errs BindErrors
+	switch rv.Kind() {
+	case reflect.Struct:
+		if rv.Type() == reflect.TypeOf(time.Time{}) || isTextType(rv.Type()) {
+			return nil
+		}
+		rv = settable(rv)
+		if errs = s.validateFields(rv, key); len(errs) == 0 {
+			var c *Config
+			errs = c.appendBindError(errs, c.callValidators(rv, key), key, rv.Type())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range rv.Len() {
+			errs = append(errs, s.validateValue(rv.Index(i), joinKey(key, fmt.Sprint(i)))...)
+		}
+	case reflect.Map:
+		_ = updateMapElements(rv, func(_ reflect.Value, name string, elem reflect.Value) error {
+			errs = append(errs, s.validateValue(elem, joinKey(key, name))...)
+			return nil
+		})
+	}
+	return errs
+}
+
+// validateFields validates the fields of the struct rv like bindStruct, but
+// without calling the Validate method of rv.
+func (s *structValidator) validateFields(rv reflect.Value, prefix string) BindErrors {
+	var c *Config
+	var errs BindErrors
+	var checked []RuleContext
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		key, ok := fieldKey(sf, prefix)
+		if !ok {
+			continue
+		}
+
+		field := rv.Field(i)
+		if sf.Anonymous {
+			errs = append(errs, s.validateValue(field, prefix)...)
+			continue
+		}
+
+		// Nested values are validated first, like Bind binds them first
+		errs = append(errs, s.validateValue(field, key)...)
+
+		ctx := RuleContext{Field: sf, Value: field, Parent: rv, Key: key, Changed: !field.IsZero()}
+		if err := validateField(ctx, false); err != nil {
+			errs = c.appendBindError(errs, err, key, sf.Type)
+			continue
+		}
+		checked = append(checked, ctx)
+	}
+
+	for ctx := range slices.Values(checked) {
+		if err := validateField(ctx, true); err != nil {
+			errs = c.appendBindError(errs, err, ctx.Key, ctx.Field.Type)
+		}
+	}
+	return errs
+}
+
+// settable returns rv, or a settable copy of it if rv can't be set.
+func settable(rv reflect.Value) reflect.Value {
+	if rv.CanSet() {
+		return rv
+	}
+	cp := reflect.New(rv.Type()).Elem()
+	cp.Set(rv)
+	return cp
+}
+
+// joinKey returns the key of the element name of key.
+func joinKey(key, name string) string {
+	if key == "" {
+		return name
+	}
+	return key + "." + name
+}
@@ -0,0 +1,95 @@
+package config_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+type structServer struct {
+	Host string `config:"host" check:"trim,hostname"`
+	Port int    `config:"port" check:"port"`
+}
+
+type structPool struct {
+	MinConns int `config:"minConns"`
+	MaxConns int `config:"maxConns" check:"gtefield=MinConns"`
+}
+
+func (p structPool) Validate() error {
+	if p.MaxConns > 100 {
+		return errors.New("too many connections")
+	}
+	return nil
+}
+
+type structApp struct {
+	Name    string                 `config:"name" check:"required,lower"`
+	Mode    string                 `config:"mode" check:"default=dev"`
+	Admins  []string               `config:"admins" check:"dive,email"`
+	Servers []structServer         `config:"servers" check:"min=1"`
+	Primary *structServer          `config:"primary"`
+	Pools   map[string]*structPool `config:"pools"`
+	Ignored string                 `config:"-" check:"required"`
+}
+
+func TestValidateStruct(t *testing.T) {
+	app := &structApp{
+		Name:    "MyApp",
+		Admins:  []string{"ops@example.com"},
+		Servers: []structServer{{Host: " api.example.com ", Port: 443}},
+		Primary: &structServer{Host: "db.internal", Port: 5432},
+		Pools:   map[string]*structPool{"main": {MinConns: 2, MaxConns: 10}},
+	}
+	if err := config.ValidateStruct(app); err != nil {
+		t.Fatalf("ValidateStruct() error = %v", err)
+	}
+	if app.Name != "myapp" || app.Mode != "dev" || app.Servers[0].Host != "api.example.com" {
+		t.Errorf("ValidateStruct() = %+v, want transforms and defaults applied", app)
+	}
+
+	invalid := structApp{
+		Admins:  []string{"ops@example.com", "nobody"},
+		Primary: &structServer{Host: "db_internal", Port: 70000},
+		Pools: map[string]*structPool{
+			"main":  {MinConns: 10, MaxConns: 2},
+			"large": {MinConns: 1, MaxConns: 500},
+		},
+	}
+	err := config.ValidateStruct(invalid)
+
+	var errs config.BindErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("ValidateStruct() error = %v, want BindErrors", err)
+	}
+	got := map[string]string{}
+	for _, e := range errs {
+		got[e.Key] = e.Rule
+	}
+	want := map[string]string{
+		"name":                "required",
+		"admins.1":            "email",
+		"servers":             "min",
+		"primary.host":        "hostname",
+		"primary.port":        "port",
+		"pools.main.maxConns": "gtefield",
+		"pools.large":         "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateStruct() errors:\nGot: %v\nWant: %v", got, want)
+	}
+	if invalid.Name != "" {
+		t.Errorf("ValidateStruct() changed a struct passed by value")
+	}
+}
+
+func TestValidateStructInput(t *testing.T) {
+	for _, v := range []any{nil, (*structApp)(nil), 42, []structApp{}} {
+		if err := config.ValidateStruct(v); err == nil || !strings.Contains(err.Error(), "input") {
+			t.Errorf("ValidateStruct(%#v) error = %v, want an input error", v, err)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package config_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nadim147c/go-config"
+)
+
+func TestValidateTags(t *testing.T) {
+	type Inner struct {
+		Pattern string `check:"match=[a-"`
+		Count   int    `check:"min=ten"`
+	}
+	type Valid struct {
+		Name  string   `check:"required,min=1,max=10"`
+		Port  int      `check:"default=8080,min=1,max=65535"`
+		Mode  string   `check:"enum='a,b'"`
+		Tags  []string `check:"min=1"`
+		Email *string  `check:"email"`
+	}
+	type Invalid struct {
+		Unknown  string  `check:"requird"`
+		Both     string  `check:"required,default=x"`
+		Quotes   string  `check:"enum='a,b"`
+		Kind     bool    `check:"min=1"`
+		Email    int     `check:"email"`
+		Default  int     `check:"default=abc"`
+		Enum     int     `check:"enum='1,x'"`
+		Nested   Inner   `config:"nested"`
+		Items    []Inner `config:"items"`
+		Ignored  string  `config:"-" check:"bogus"`
+		internal string  `check:"bogus"`
+	}
+
+	if err := config.ValidateTags(reflect.TypeFor[Valid]()); err != nil {
+		t.Errorf("ValidateTags(Valid) error = %v", err)
+	}
+
+	err := config.ValidateTags(reflect.TypeFor[*Invalid]())
+	if err == nil {
+		t.Fatal("ValidateTags(Invalid) should fail")
+	}
+
+	got := map[string]string{}
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var te config.TagError
+		if !errors.As(e, &te) {
+			t.Fatalf("error %v is not a TagError", e)
+		}
+		got[te.Field] = te.Rule
+	}
+	want := map[string]string{
+		"Unknown":        "requird",
+		"Both":           "",
+		"Quotes":         "",
+		"Kind":           "min",
+		"Email":          "email",
+		"Default":        "default",
+		"Enum":           "enum",
+		"Nested.Pattern": "match",
+		"Nested.Count":   "min",
+		"Items.Pattern":  "match",
+		"Items.Count":    "min",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateTags() fields:\nGot: %v\nWant: %v", got, want)
+	}
+}
+
+func TestBindInvalidTagDoesNotPanic(t *testing.T) {
+	c := config.New()
+	c.Set("app.name", "MyApp")
+
+	var app struct {
+		Name string `config:"name" check:"requird"`
+		Port int    `config:"port" check:"required,default=80"`
+	}
+	err := c.Bind("app", &app)
+
+	var te config.TagError
+	if !errors.As(err, &te) {
+		t.Fatalf("Bind() error = %v, want TagError", err)
+	}
+	if !strings.Contains(err.Error(), `unknown validation rule "requird"`) {
+		t.Errorf("Bind() error = %v, want unknown rule", err)
+	}
+	if !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("Bind() error = %v, want mutually exclusive rules", err)
+	}
+}
+
+func TestValidateDurationAndTime(t *testing.T) {
+	type Limits struct {
+		Timeout time.Duration  `check:"min=1s,max=1h"`
+		Retry   *time.Duration `check:"min=100ms"`
+		Start   time.Time      `check:"after=2020-01-01"`
+		Expiry  time.Time      `check:"before=now"`
+		Window  time.Time      `check:"after=2020-01-01T00:00:00Z,before=2030-01-01"`
+	}
+
+	retry := 50 * time.Millisecond
+	tests := []struct {
+		field   string
+		value   any
+		wantErr string
+	}{
+		{"Timeout", 30 * time.Second, ""},
+		{"Timeout", time.Second, ""},
+		{"Timeout", 500 * time.Millisecond, "500ms is less than the minimum (1s)"},
+		{"Timeout", 2 * time.Hour, "2h0m0s is greater than the maximum (1h0m0s)"},
+		{"Retry", &retry, "50ms is less than the minimum (100ms)"},
+		{"Start", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), ""},
+		{"Start", time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC), "2019-05-01T00:00:00Z is not after 2020-01-01T00:00:00Z"},
+		{"Expiry", time.Now().Add(-time.Hour), ""},
+		{"Expiry", time.Now().Add(time.Hour), "is not before"},
+		{"Window", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), ""},
+		{"Window", time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC), "is not before 2030-01-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			sf, _ := reflect.TypeFor[Limits]().FieldByName(tt.field)
+			value := reflect.New(sf.Type).Elem()
+			value.Set(reflect.ValueOf(tt.value))
+
+			err := config.Validate(sf, value, true)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate(%v) error = %v", tt.value, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate(%v) error = %v, want %q", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+
+	type Invalid struct {
+		Timeout time.Duration `check:"min=soon"`
+		Start   time.Time     `check:"after=someday"`
+		Count   int           `check:"before=now"`
+	}
+	err := config.ValidateTags(reflect.TypeFor[Invalid]())
+	for field := range strings.SplitSeq("Timeout Start Count", " ") {
+		if err == nil || !strings.Contains(err.Error(), "on "+field+":") {
+			t.Errorf("ValidateTags() error = %v, want %s", err, field)
+		}
+	}
+}
+
+func TestValidateNilTime(t *testing.T) {
+	type Window struct {
+		Start *time.Time `config:"start" check:"after=2020-01-01"`
+		End   *time.Time `config:"end" check:"before=2030-01-01"`
+	}
+
+	var w Window
+	if err := config.New().Bind("window", &w); err != nil {
+		t.Errorf("Bind() error = %v", err)
+	}
+	if err := config.ValidateStruct(&Window{}); err != nil {
+		t.Errorf("ValidateStruct() error = %v", err)
+	}
+
+	start := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	if err := config.ValidateStruct(&Window{Start: &start}); err == nil {
+		t.Error("ValidateStruct() should fail for a start before 2020")
+	}
+}
+
+func TestValidateNilString(t *testing.T) {
+	type Contact struct {
+		Mail *string `config:"mail" check:"email"`
+		Code *string `config:"code" check:"trim,upper,alpha"`
+		Cert *string `config:"cert" check:"expand,file,readable"`
+	}
+
+	if err := config.ValidateTags(reflect.TypeFor[Contact]()); err != nil {
+		t.Fatalf("ValidateTags() error = %v", err)
+	}
+	var contact Contact
+	if err := config.New().Bind("contact", &contact); err != nil {
+		t.Errorf("Bind() error = %v", err)
+	}
+	if err := config.ValidateStruct(&Contact{}); err != nil {
+		t.Errorf("ValidateStruct() error = %v", err)
+	}
+
+	mail := "not an email"
+	if err := config.ValidateStruct(&Contact{Mail: &mail}); err == nil {
+		t.Error("ValidateStruct() should fail for an invalid email")
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import "reflect"
+
+// Validator is implemented by structs that check invariants which don't fit
+// in "check" tags. Bind calls Validate on every struct it binds, nested ones
+// included, once the fields of the struct are bound and valid.
+//
+// Example:
+//
+//	func (p *Pool) Validate() error {
+//		if p.MaxConns%p.Shards != 0 {
+//			return errors.New("maxConns must be a multiple of shards")
+//		}
+//		return nil
+//	}
+type Validator interface {
+	Validate() error
+}
+
+// ValidatorWithConfig is like Validator, but gets the Config being bound, so
+// the invariants can depend on other keys.
+type ValidatorWithConfig interface {
+	ValidateConfig(c *Config) error
+}
+
+// callValidators calls the Validate and ValidateConfig methods of the struct
+// rv, if it has them, and returns the first error as a BindError for key. c
+// may be nil, as in ValidateStruct; ValidateConfig isn't called then.
+func (c *Config) callValidators(rv reflect.Value, key string) error {
+	v := rv.Interface()
+	if rv.CanAddr() {
+		v = rv.Addr().Interface()
+	}
+
+	var err error
+	if validator, ok := v.(Validator); ok {
+		err = validator.Validate()
+	}
+	if validator, ok := v.(ValidatorWithConfig); ok && err == nil && c != nil {
+		err = validator.ValidateConfig(c)
+	}
+	if err == nil {
+		return nil
+	}
+
+	be := BindError{Key: key, Type: rv.Type(), Err: err}
+	if c == nil {
+		return be
+	}
+	if origin, err := c.Origin(key); err == nil {
+		be.Value = origin.Value
+		be.Origin = origin
+	}
+	return be
+}
@@ -0,0 +1,90 @@
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+type hookPool struct {
+	MinConns int `config:"minConns"`
+	MaxConns int `config:"maxConns" check:"min=1"`
+}
+
+func (p *hookPool) Validate() error {
+	if p.MaxConns%p.MinConns != 0 {
+		return errors.New("maxConns must be a multiple of minConns")
+	}
+	return nil
+}
+
+type hookApp struct {
+	Name string   `config:"name"`
+	Pool hookPool `config:"pool"`
+}
+
+func (a hookApp) ValidateConfig(c *config.Config) error {
+	if c.GetString("env") == "prod" && a.Name == "" {
+		return errors.New("name is required in prod")
+	}
+	return nil
+}
+
+func TestValidatorHooks(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]any
+		wantKey string
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			values: map[string]any{"app.pool.minConns": 2, "app.pool.maxConns": 10},
+		},
+		{
+			name:    "nested Validate",
+			values:  map[string]any{"app.pool.minConns": 3, "app.pool.maxConns": 10},
+			wantKey: "app.pool",
+			wantErr: "app.pool: maxConns must be a multiple of minConns",
+		},
+		{
+			name:    "top-level ValidateConfig",
+			values:  map[string]any{"env": "prod", "app.pool.minConns": 2, "app.pool.maxConns": 10},
+			wantKey: "app",
+			wantErr: "app: name is required in prod",
+		},
+		{
+			name:    "skipped after a tag error",
+			values:  map[string]any{"env": "prod", "app.pool.minConns": 3, "app.pool.maxConns": 0},
+			wantKey: "app.pool.maxConns",
+			wantErr: "app.pool.maxConns: min: 0 is less than the minimum (1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := config.New()
+			for key, value := range tt.values {
+				c.Set(key, value)
+			}
+
+			var app hookApp
+			err := c.Bind("app", &app)
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Errorf("Bind() error = %v", err)
+				}
+				return
+			}
+
+			var errs config.BindErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Bind() error = %v, want BindErrors", err)
+			}
+			if len(errs) != 1 || errs[0].Key != tt.wantKey || errs[0].Error() != tt.wantErr {
+				t.Errorf("Bind() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"errors"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultWatchDebounce is the delay used by WatchConfig to group file changes
+// into a single reload when no other delay is set.
+const DefaultWatchDebounce = 100 * time.Millisecond
+
+// Event describes a config reload triggered by WatchConfig.
+type Event struct {
+	// Paths lists the changed files that triggered the reload.
+	Paths []string
+	// Err is the error returned by ReadConfig while reloading, if any.
+	Err error
+}
+
+// watchState holds the file watcher of a Config.
+type watchState struct {
+	mu        sync.Mutex
+	watcher   *fsnotify.Watcher
+	callbacks []func(Event)
+	debounce  time.Duration
+	timer     *time.Timer
+	pending   map[string]bool
+
+	// dirs are the watched directories, files the watched config files and
+	// search the directories added with AddPath.
+	dirs   map[string]bool
+	files  map[string]bool
+	search map[string]bool
+}
+
+// OnConfigChange registers a callback that is called after WatchConfig
+// reloads the configuration.
+func (c *Config) OnConfigChange(fn func(Event)) {
+	c.watch.mu.Lock()
+	defer c.watch.mu.Unlock()
+	c.watch.callbacks = append(c.watch.callbacks, fn)
+}
+
+// SetWatchDebounce sets how long WatchConfig waits for further changes before
+// reloading. Editors often write a file in several steps; the debounce groups
+// them into a single reload.
+func (c *Config) SetWatchDebounce(d time.Duration) {
+	c.watch.mu.Lock()
+	defer c.watch.mu.Unlock()
+	c.watch.debounce = d
+}
+
+// WatchConfig watches every file returned by GetConfigFiles, every file pulled
+// in through "include" and every directory added with AddPath. When one of
+// them changes, ReadConfig is called again and the OnConfigChange callbacks
+// are fired. Paths added with AddPath or AddFile later are watched too.
+// Calling WatchConfig while already watching is a no-op.
+//
+// Parent directories are watched instead of the files themselves, so editors
+// that replace a file by renaming a new one over it are handled, and config
+// files created later in an AddPath directory are picked up.
+func (c *Config) WatchConfig() error {
+	c.watch.mu.Lock()
+	defer c.watch.mu.Unlock()
+
+	if c.watch.watcher != nil {
+		return nil
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	c.watch.watcher = w
+	c.watch.dirs = map[string]bool{}
+	c.watch.pending = map[string]bool{}
+	c.updateWatchTargets()
+
+	go c.watchLoop(w)
+	return nil
+}
+
+// StopWatchConfig stops the watcher started by WatchConfig. Pending reloads are
+// discarded.
+func (c *Config) StopWatchConfig() error {
+	c.watch.mu.Lock()
+	defer c.watch.mu.Unlock()
+
+	if c.watch.watcher == nil {
+		return errors.New("config is not being watched")
+	}
+	if c.watch.timer != nil {
+		c.watch.timer.Stop()
+		c.watch.timer = nil
+	}
+	err := c.watch.watcher.Close()
+	c.watch.watcher = nil
+	return err
+}
+
+// watchNewPaths watches the paths added with AddPath or AddFile while
+// WatchConfig is running. The caller must not hold c.mu.
+func (c *Config) watchNewPaths() {
+	c.watch.mu.Lock()
+	defer c.watch.mu.Unlock()
+	if c.watch.watcher != nil {
+		c.updateWatchTargets()
+	}
+}
+
+// updateWatchTargets recomputes the watched files and adds any new parent
+// directory to the watcher. The caller must hold c.watch.mu but not c.mu.
+func (c *Config) updateWatchTargets() {
+	files := map[string]bool{}
+	search := map[string]bool{}
+	dirs := map[string]bool{}
+
+	c.mu.Lock()
+	paths := slices.Clone(c.paths)
+	fullPath := maps.Clone(c.fullPath)
+	c.mu.Unlock()
+
+	for path := range slices.Values(paths) {
+		full := fullPath[path]
+		path, err := FindPath("", path)
+		if err != nil {
+			continue
+		}
+		if full {
+			files[path] = true
+			dirs[filepath.Dir(path)] = true
+		} else {
+			search[path] = true
+			dirs[path] = true
+		}
+	}
+
+	for path := range slices.Values(c.load().files) {
+		files[path] = true
+		dirs[filepath.Dir(path)] = true
+	}
+
+	for dir := range dirs {
+		if c.watch.dirs[dir] {
+			continue
+		}
+		if err := c.watch.watcher.Add(dir); err != nil {
+			c.GetLogger().Debug("Failed to watch directory", "path", dir, "error", err)
+			continue
+		}
+		c.watch.dirs[dir] = true
+	}
+
+	c.watch.files = files
+	c.watch.search = search
+}
+
+func (c *Config) watchLoop(w *fsnotify.Watcher) {
+	for {
+		select {
+		case event, ok := <-w.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			c.scheduleReload(event.Name)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return
+			}
+			c.GetLogger().Warn("Config watcher error", "error", err)
+		}
+	}
+}
+
+// scheduleReload queues a reload if path is a watched config file, resetting
+// the debounce timer.
+func (c *Config) scheduleReload(path string) {
+	c.watch.mu.Lock()
+	defer c.watch.mu.Unlock()
+
+	if c.watch.watcher == nil {
+		return
+	}
+
+	path = filepath.Clean(path)
+	relevant := c.watch.files[path] ||
+		c.watch.search[filepath.Dir(path)] && basenameWithoutExt(path) == c.fileName
+	if !relevant {
+		return
+	}
+
+	c.GetLogger().Debug("Config file changed", "path", path)
+	c.watch.pending[path] = true
+
+	debounce := c.watch.debounce
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+	// A timer that already fired has a reload waiting for c.watch.mu, which
+	// takes the pending paths, so it can't be reused.
+	if c.watch.timer != nil && c.watch.timer.Stop() {
+		c.watch.timer.Reset(debounce)
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(debounce, func() { c.reload(&timer) })
+	c.watch.timer = timer
+}
+
+// reload re-reads the config and notifies the OnConfigChange callbacks. timer
+// points to the timer that called it; it is read with c.watch.mu held.
+func (c *Config) reload(timer **time.Timer) {
+	c.watch.mu.Lock()
+	if c.watch.timer == *timer {
+		c.watch.timer = nil
+	}
+	// The paths may have been taken by a reload whose timer fired while this
+	// one was scheduled.
+	if c.watch.watcher == nil || len(c.watch.pending) == 0 {
+		c.watch.mu.Unlock()
+		return
+	}
+	paths := make([]string, 0, len(c.watch.pending))
+	for path := range c.watch.pending {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	c.watch.pending = map[string]bool{}
+	c.watch.mu.Unlock()
+
+	err := c.ReadConfig()
+	if err != nil {
+		c.GetLogger().Warn("Failed to reload config", "error", err)
+	}
+
+	c.watch.mu.Lock()
+	if c.watch.watcher != nil {
+		// Includes may have changed, so watch any new directories.
+		c.updateWatchTargets()
+	}
+	callbacks := slices.Clone(c.watch.callbacks)
+	c.watch.mu.Unlock()
+
+	event := Event{Paths: paths, Err: err}
+	for fn := range slices.Values(callbacks) {
+		fn(event)
+	}
+}
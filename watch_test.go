@@ -0,0 +1,120 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Nadim147c/go-config"
+)
+
+func waitEvent(t *testing.T, events <-chan config.Event) config.Event {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for config change")
+	}
+	return config.Event{}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatchConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	included := filepath.Join(dir, "base.json")
+	writeFile(t, path, "include: base.json\napp:\n  port: 8080\n")
+	writeFile(t, included, `{"app": {"env": "dev"}}`)
+
+	c := config.New()
+	c.AddPath(dir)
+	if err := c.ReadConfig(); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+
+	events := make(chan config.Event, 10)
+	c.OnConfigChange(func(e config.Event) { events <- e })
+	c.SetWatchDebounce(50 * time.Millisecond)
+	if err := c.WatchConfig(); err != nil {
+		t.Fatalf("WatchConfig() error = %v", err)
+	}
+	defer c.StopWatchConfig()
+
+	t.Run("replace by rename", func(t *testing.T) {
+		tmp := filepath.Join(t.TempDir(), "config.yaml")
+		writeFile(t, tmp, "include: base.json\napp:\n  port: 9090\n")
+		if err := os.Rename(tmp, path); err != nil {
+			t.Fatal(err)
+		}
+
+		e := waitEvent(t, events)
+		if e.Err != nil {
+			t.Fatalf("reload error = %v", e.Err)
+		}
+		if port := c.GetIntMust("app.port"); port != 9090 {
+			t.Fatalf("app.port = %d, want %d", port, 9090)
+		}
+	})
+
+	t.Run("included file", func(t *testing.T) {
+		writeFile(t, included, `{"app": {"env": "prod"}}`)
+
+		e := waitEvent(t, events)
+		if len(e.Paths) != 1 || e.Paths[0] != included {
+			t.Fatalf("event paths = %v, want [%s]", e.Paths, included)
+		}
+		if env := c.GetStringMust("app.env"); env != "prod" {
+			t.Fatalf("app.env = %q, want %q", env, "prod")
+		}
+	})
+
+	t.Run("new file in search path", func(t *testing.T) {
+		writeFile(t, filepath.Join(dir, "config.toml"), "[app]\nname = \"MyApp\"\n")
+
+		waitEvent(t, events)
+		if name := c.GetStringMust("app.name"); name != "MyApp" {
+			t.Fatalf("app.name = %q, want %q", name, "MyApp")
+		}
+	})
+
+	t.Run("file added while watching", func(t *testing.T) {
+		other := filepath.Join(t.TempDir(), "local.json")
+		writeFile(t, other, `{"app": {"region": "eu"}}`)
+		c.AddFile(other)
+
+		writeFile(t, other, `{"app": {"region": "us"}}`)
+		e := waitEvent(t, events)
+		if len(e.Paths) != 1 || e.Paths[0] != other {
+			t.Fatalf("event paths = %v, want [%s]", e.Paths, other)
+		}
+		if region := c.GetStringMust("app.region"); region != "us" {
+			t.Fatalf("app.region = %q, want %q", region, "us")
+		}
+	})
+
+	t.Run("no reload without changes", func(t *testing.T) {
+		for range 20 {
+			writeFile(t, included, `{"app": {"env": "prod"}}`)
+			time.Sleep(5 * time.Millisecond)
+		}
+		e := waitEvent(t, events)
+		if len(e.Paths) == 0 {
+			t.Fatal("event without paths")
+		}
+		select {
+		case e := <-events:
+			if len(e.Paths) == 0 {
+				t.Fatalf("extra reload without paths: %+v", e)
+			}
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+}
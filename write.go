@@ -0,0 +1,148 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// WriteConfig writes the current settings to the last file returned by
+// GetConfigFiles, which is the file with the highest precedence. It returns an
+// error if no config file has been found, or for a view created by Sub, whose
+// section would replace the whole file.
+func (c *Config) WriteConfig() error {
+	if c.parent != nil {
+		return errors.New("cannot write a Sub view to a config file of its parent; use WriteConfigAs")
+	}
+	paths := c.GetConfigFiles()
+	if len(paths) == 0 {
+		return errors.New("no config file to write")
+	}
+	return c.WriteConfigAs(paths[len(paths)-1])
+}
+
+// WriteConfigAs writes the current settings (config merged with defaults) to
+// the given path, replacing the file if it already exists. The encoder is
+// chosen from the file extension, falling back to the default format.
+//
+// The file is written to a temporary file in the same directory and renamed
+// into place, so readers never observe a partially written config.
+func (c *Config) WriteConfigAs(path string) error {
+	return c.writeConfig(path, true)
+}
+
+// SafeWriteConfigAs writes the current settings to the given path like
+// WriteConfigAs, but returns an error wrapping os.ErrExist instead of
+// replacing an existing file.
+func (c *Config) SafeWriteConfigAs(path string) error {
+	return c.writeConfig(path, false)
+}
+
+func (c *Config) writeConfig(path string, overwrite bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path, err := FindPath("", path)
+	if err != nil {
+		return err
+	}
+
+	if !overwrite {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("config file %s: %w", path, os.ErrExist)
+		}
+	}
+
+	b, err := c.encode(path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %v", err)
+	}
+
+	return writeFileAtomic(path, b, overwrite)
+}
+
+// encode serializes the effective settings with the encoder for the path's
+// extension. The caller must hold c.mu.
+func (c *Config) encode(path string) ([]byte, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+
+	encoder, ok := c.encoders[ext]
+	if !ok {
+		encoder, ok = c.encoders[c.defaultFormat]
+		if !ok {
+			return nil, fmt.Errorf("encoder not found for format: %v", ext)
+		}
+	}
+
+	// Merge from the lowest precedence up so the order set by SetPrecedence
+	// is kept.
+	s := c.load()
+	settings := map[string]any{}
+	for _, e := range slices.Backward(s.providers) {
+		switch e.layer {
+		case LayerFile:
+			DeepMerge(settings, c.fileSettings())
+		case LayerDefaults:
+			DeepMerge(settings, c.defaultSettings())
+		}
+	}
+
+	b, err := encoder(settings)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", ext, err)
+	}
+	return b, nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and moves it
+// into place. When overwrite is false the final step fails if path exists.
+func writeFileAtomic(path string, data []byte, overwrite bool) (err error) {
+	perm := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %v", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return fmt.Errorf("failed to write config: %v", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("failed to sync config: %v", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return fmt.Errorf("failed to set config permissions: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close config: %v", err)
+	}
+
+	if overwrite {
+		return os.Rename(tmp.Name(), path)
+	}
+
+	// Link fails if path exists, which keeps the no-clobber check atomic.
+	if err := os.Link(tmp.Name(), path); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("config file %s: %w", path, os.ErrExist)
+		}
+		return err
+	}
+	return os.Remove(tmp.Name())
+}
@@ -0,0 +1,155 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Nadim147c/go-config"
+)
+
+func TestWriteConfigAs(t *testing.T) {
+	formats := []string{"json", "jsonc", "hjson", "yaml", "yml", "toml"}
+
+	for _, format := range formats {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config."+format)
+
+			c := config.New()
+			c.SetDefault("app.env", "development")
+			c.SetDefault("app.port", 80)
+			c.Set("app.port", 8080)
+			c.Set("app.name", "MyApp")
+
+			if err := c.WriteConfigAs(path); err != nil {
+				t.Fatalf("WriteConfigAs() error = %v", err)
+			}
+
+			r := config.New()
+			r.AddFile(path)
+			if err := r.ReadConfig(); err != nil {
+				t.Fatalf("ReadConfig() error = %v", err)
+			}
+
+			if v := r.GetStringMust("app.name"); v != "MyApp" {
+				t.Errorf("app.name = %q, want %q", v, "MyApp")
+			}
+			if v := r.GetIntMust("app.port"); v != 8080 {
+				t.Errorf("app.port = %d, want %d", v, 8080)
+			}
+			if v := r.GetStringMust("app.env"); v != "development" {
+				t.Errorf("app.env = %q, want %q", v, "development")
+			}
+		})
+	}
+}
+
+func TestWriteConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"app":{"port":1}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := config.New()
+	c.AddPath(dir)
+	if err := c.ReadConfig(); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	c.Set("app.port", 2)
+
+	if err := c.WriteConfig(); err != nil {
+		t.Fatalf("WriteConfig() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("file mode = %v, want %v", info.Mode().Perm(), os.FileMode(0o600))
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory contains %d entries, want 1", len(entries))
+	}
+
+	if err := c.ReadConfig(); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	if v := c.GetIntMust("app.port"); v != 2 {
+		t.Errorf("app.port = %d, want %d", v, 2)
+	}
+}
+
+func TestWriteConfigWithoutFile(t *testing.T) {
+	c := config.New()
+	if err := c.WriteConfig(); err == nil {
+		t.Fatal("expected error but got none")
+	}
+}
+
+func TestSafeWriteConfigAs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+
+	c := config.New()
+	c.Set("app.port", 8080)
+
+	if err := c.SafeWriteConfigAs(path); err != nil {
+		t.Fatalf("SafeWriteConfigAs() error = %v", err)
+	}
+
+	err := c.SafeWriteConfigAs(path)
+	if !errors.Is(err, os.ErrExist) {
+		t.Fatalf("SafeWriteConfigAs() error = %v, want %v", err, os.ErrExist)
+	}
+}
+
+func TestWriteConfigAsSub(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.toml")
+
+	c := config.New()
+	c.SetDefault("plugins.cache.ttl", "1m")
+	c.Set("plugins.cache.size", 64)
+	c.Set("plugins.auth.enabled", true)
+
+	if err := c.Sub("plugins.cache").WriteConfigAs(path); err != nil {
+		t.Fatalf("WriteConfigAs() error = %v", err)
+	}
+
+	r := config.New()
+	r.AddFile(path)
+	if err := r.ReadConfig(); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+
+	if v := r.GetIntMust("size"); v != 64 {
+		t.Errorf("size = %d, want %d", v, 64)
+	}
+	if v := r.GetStringMust("ttl"); v != "1m" {
+		t.Errorf("ttl = %q, want %q", v, "1m")
+	}
+	if _, err := r.GetE("enabled"); err == nil {
+		t.Error("enabled should not be written for the plugins.cache view")
+	}
+
+	c.AddFile(path)
+	if err := c.ReadConfig(); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Sub("plugins.cache").WriteConfig(); err == nil {
+		t.Error("WriteConfig() on a view should fail")
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Errorf("WriteConfig() on a view changed %s", path)
+	}
+}